@@ -0,0 +1,181 @@
+// Command fmodreplay plays back a Studio command capture without an audio device.
+//
+// The Studio System is initialized with the "lowlevel.OUTPUTTYPE_NOSOUND_NRT" output, so the capture is mixed
+// as fast as possible and can be replayed headlessly, for example on a CI machine.
+//
+// Usage:
+//
+//	fmodreplay [flags] capture.cmd.raw
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/theaidem/fmod/lowlevel"
+	"github.com/theaidem/fmod/studio"
+)
+
+var (
+	bankPath    = flag.String("bankpath", "", "directory the captured banks are loaded from")
+	list        = flag.Bool("list", false, "list the captured commands and exit")
+	seek        = flag.Float64("seek", 0, "start the replay at this time, in seconds")
+	step        = flag.Bool("step", false, "step through the replay one frame at a time")
+	verbose     = flag.Bool("v", false, "print every command as it is replayed")
+	skipCleanup = flag.Bool("skipcleanup", false, "keep the resources created by the replay when it stops")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] capture\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(filename string) error {
+	system, err := studio.SystemCreate()
+	if err != nil {
+		return err
+	}
+	defer system.Release()
+
+	lowLevel, err := system.LowLevelSystem()
+	if err != nil {
+		return err
+	}
+
+	// Must be selected before the Studio System is initialized
+	err = lowLevel.SetOutput(lowlevel.OUTPUTTYPE_NOSOUND_NRT)
+	if err != nil {
+		return err
+	}
+
+	err = system.Initialize(1024, studio.INIT_NORMAL, lowlevel.INIT_NORMAL, 0)
+	if err != nil {
+		return err
+	}
+
+	flags := studio.COMMANDREPLAY_NORMAL
+	if *skipCleanup {
+		flags |= studio.COMMANDREPLAY_SKIP_CLEANUP
+	}
+
+	replay, err := system.LoadCommandReplay(filename, flags)
+	if err != nil {
+		return err
+	}
+	defer replay.Release()
+
+	if *list {
+		return listCommands(replay)
+	}
+
+	if *bankPath != "" {
+		err = replay.SetBankPath(*bankPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	length, err := replay.Length()
+	if err != nil {
+		return err
+	}
+	log.Printf("replaying %s (%.2fs)", filename, length)
+
+	err = replay.Start()
+	if err != nil {
+		return err
+	}
+
+	if *seek > 0 {
+		err = replay.SeekToTime(*seek)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *step {
+		err = replay.SetPaused(true)
+		if err != nil {
+			return err
+		}
+	}
+
+	last := -1
+	for {
+		err = system.Update()
+		if err != nil {
+			return err
+		}
+
+		state, err := replay.PlaybackState()
+		if err != nil {
+			return err
+		}
+		if state == studio.PLAYBACK_STOPPED {
+			break
+		}
+
+		index, time, err := replay.CurrentCommand()
+		if err != nil {
+			return err
+		}
+		if *verbose && index != last {
+			for i := last + 1; i <= index; i++ {
+				printCommand(replay, i)
+			}
+			last = index
+		}
+
+		if *step {
+			fmt.Fprintf(os.Stderr, "%.3fs command %d, press enter to step ", time, index)
+			fmt.Scanln()
+			err = replay.StepFrame()
+			if err == studio.ErrLastFrame {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Print("replay finished")
+	return replay.Stop()
+}
+
+func listCommands(replay *studio.CommandReplay) error {
+	count, err := replay.CommandCount()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		printCommand(replay, i)
+	}
+	return nil
+}
+
+func printCommand(replay *studio.CommandReplay, index int) {
+	info, err := replay.CommandInfo(index)
+	if err != nil {
+		log.Printf("%6d: %v", index, err)
+		return
+	}
+	command, err := replay.CommandString(index)
+	if err != nil {
+		command = info.CommandName
+	}
+	fmt.Printf("%6d frame %6d %9.3fs %s\n", index, info.FrameNumber, info.FrameTime, command)
+}
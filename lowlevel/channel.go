@@ -2,6 +2,7 @@ package lowlevel
 
 /*
 #include <fmod.h>
+extern FMOD_RESULT goChannelControlCallback(FMOD_CHANNELCONTROL *channelcontrol, FMOD_CHANNELCONTROL_TYPE controltype, FMOD_CHANNELCONTROL_CALLBACK_TYPE callbacktype, void *commanddata1, void *commanddata2);
 */
 import "C"
 import "unsafe"
@@ -198,15 +199,27 @@ func (c *Channel) Mode() (Mode, error) {
 	return Mode(mode), errs[res]
 }
 
-// NOTE: Not implement yet
 // Sets a callback to perform action for a specific event.
 // Currently callbacks are driven by "System.Update" and will only occur when this function is called. This has the main advantage of far less complication due to thread issues,
 // and allows all FMOD commands, including loading sounds and playing new sounds from the callback.
 // It also allows any type of sound to have an end callback, no matter what it is. The only disadvantage is that callbacks are not asynchronous and are bound by the latency caused by
 // the rate the user calls the update command.
-func (c *Channel) SetCallback(callback C.FMOD_CHANNELCONTROL_CALLBACK) error {
-	//FMOD_RESULT F_API FMOD_Channel_SetCallback(FMOD_CHANNEL *channel, FMOD_CHANNELCONTROL_CALLBACK callback);
-	return ErrNoImpl
+//
+// callback: Called with a "ChannelEvent" for every callback type. Pass nil to remove the callback.
+//
+// Channels are released when their sound ends, so the callback of a Channel is removed automatically after "CHANNELCONTROL_CALLBACK_END".
+func (c *Channel) SetCallback(callback func(ev ChannelEvent)) error {
+	if callback == nil {
+		res := C.FMOD_Channel_SetCallback(c.cptr, nil)
+		setChannelCallback(unsafe.Pointer(c.cptr), nil)
+		return errs[res]
+	}
+	setChannelCallback(unsafe.Pointer(c.cptr), callback)
+	res := C.FMOD_Channel_SetCallback(c.cptr, (C.FMOD_CHANNELCONTROL_CALLBACK)(unsafe.Pointer(C.goChannelControlCallback)))
+	if res != C.FMOD_OK {
+		setChannelCallback(unsafe.Pointer(c.cptr), nil)
+	}
+	return errs[res]
 }
 
 // Retrieves the playing state.
@@ -263,52 +276,48 @@ func (c *Channel) SetMixLevelsOutput(frontleft, frontright, center, lfe, surroun
 	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Sets the incoming volume level for each channel, this is a helper to avoid calling "Channel.SetMixMatrix".
 // This means if you have multichannel audio you can turn channels on and off, a mono signal has 1 input channel, stereo has 2, etc.
 //
-// levels: Array of volume levels for each incoming channel.
-//
-// numlevels: Number of levels in the array, from 0 to 32 inclusive.
+// levels: Volume levels for each incoming channel, from 0 to MAX_CHANNEL_WIDTH levels inclusive.
 //
 // Levels can be below 0 to invert a signal and above 1 to amplify the signal. Note that increasing the signal level too far may cause audible distortion.
-func (c *Channel) SetMixLevelsInput(levels *C.float, numlevels C.int) error {
-	//FMOD_RESULT F_API FMOD_Channel_SetMixLevelsInput(FMOD_CHANNEL *channel, float *levels, int numlevels);
-	return ErrNoImpl
+func (c *Channel) SetMixLevelsInput(levels []float32) error {
+	if len(levels) > MAX_CHANNEL_WIDTH {
+		return errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	var clevels *C.float
+	if len(levels) > 0 {
+		clevels = (*C.float)(unsafe.Pointer(&levels[0]))
+	}
+	res := C.FMOD_Channel_SetMixLevelsInput(c.cptr, clevels, C.int(len(levels)))
+	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Sets a 2D pan matrix that maps input channels (columns) to output speakers (rows).
 //
-// matrix: Address of a 2 dimensional array of volume levels in row-major order. Each row represents an output speaker, each column represents an input channel.
-//
-// outchannels: Number of output channels (rows) in the matrix being passed in, from 0 to MAX_CHANNEL_WIDTH inclusive.
-//
-// inchannels: Number of input channels (columns) in the matrix being passed in, from 0 to MAX_CHANNEL_WIDTH inclusive.
-//
-// matrixhop: The width (total number of columns) of the matrix. Optional. If this is 0, inchannels will be taken as the width of the matrix. Maximum of MAX_CHANNEL_WIDTH.
-//
-// The gain for input channel 's' to output channel 't' is matrix[t * matrixhop + s].
+// matrix: The "MixMatrix" to apply, from 0 to MAX_CHANNEL_WIDTH rows and columns inclusive.
+// Use "System.DownmixMatrix", "System.UpmixMatrix" or "System.IdentityMixMatrix" to start from a standard conversion.
 //
 // Levels can be below 0 to invert a signal and above 1 to amplify the signal. Note that increasing the signal level too far may cause audible distortion.
 // The matrix size will generally be the size of the number of channels in the current speaker mode. Use "System.SoftwareFormat" to determine this.
 // If a matrix already exists then the matrix passed in will applied over the top of it. The input matrix can be smaller than the existing matrix.
-func (c *Channel) SetMixMatrix(matrix *C.float, outchannels, inchannels, inchannel_hop C.int) error {
-	//FMOD_RESULT F_API FMOD_Channel_SetMixMatrix(FMOD_CHANNEL *channel, float *matrix, int outchannels, int inchannels, int inchannel_hop);
-	return ErrNoImpl
+func (c *Channel) SetMixMatrix(matrix *MixMatrix) error {
+	if err := matrix.validate(); err != nil {
+		return err
+	}
+	res := C.FMOD_Channel_SetMixMatrix(c.cptr, matrix.levels(), C.int(matrix.Out), C.int(matrix.In), C.int(matrix.In))
+	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieves a 2D pan matrix that maps input channels (columns) to output speakers (rows).
 //
-// The gain for input channel 's' to output channel 't' is matrix[t * matrixhop + s].
-//
 // Levels can be below 0 to invert a signal and above 1 to amplify the signal. Note that increasing the signal level too far may cause audible distortion.
 // The matrix size will generally be the size of the number of channels in the current speaker mode. Use "System.SoftwareFormat" to determine this.
-// Passing NULL for 'matrix' will allow you to query 'outchannels' and 'inchannels' without copying any data.
-func (c *Channel) MixMatrix(matrix *C.float, outchannels, inchannels *C.int, inchannel_hop C.int) error {
-	//FMOD_RESULT F_API FMOD_Channel_GetMixMatrix(FMOD_CHANNEL *channel, float *matrix, int *outchannels, int *inchannels, int inchannel_hop);
-	return ErrNoImpl
+func (c *Channel) MixMatrix() (*MixMatrix, error) {
+	return getMixMatrix(func(matrix *C.float, outchannels, inchannels *C.int, inchannel_hop C.int) C.FMOD_RESULT {
+		return C.FMOD_Channel_GetMixMatrix(c.cptr, matrix, outchannels, inchannels, inchannel_hop)
+	})
 }
 
 /*
@@ -0,0 +1,88 @@
+package lowlevel
+
+/*
+#include <fmod.h>
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+// A callback raised for a "Channel" or a "ChannelGroup", see "Channel.SetCallback".
+// Only the fields matching Type are set.
+type ChannelEvent struct {
+	// The callback type.
+	Type ChannelControlCallbackType
+
+	// Whether the callback was raised for a Channel or a ChannelGroup.
+	ControlType ChannelControlType
+
+	// The channel the callback was raised for, if ControlType is "CHANNELCONTROL_CHANNEL".
+	Channel *Channel
+
+	// The channel group the callback was raised for, if ControlType is "CHANNELCONTROL_CHANNELGROUP".
+	ChannelGroup *ChannelGroup
+
+	// For "CHANNELCONTROL_CALLBACK_VIRTUALVOICE": true when the voice was swapped from real to virtual,
+	// false when it was swapped from virtual to real.
+	Virtual bool
+
+	// For "CHANNELCONTROL_CALLBACK_SYNCPOINT": the index of the sync point, see "Sound.SyncPoint".
+	SyncPoint int
+
+	// For "CHANNELCONTROL_CALLBACK_OCCLUSION": the calculated direct and reverb occlusion values.
+	// They can be modified to clamp or change the occlusion, and are only valid while the callback runs.
+	Direct, Reverb *float32
+}
+
+// Registered channel callbacks, keyed by Channel or ChannelGroup pointer.
+var channelCallbacks = struct {
+	sync.Mutex
+	m map[uintptr]func(ChannelEvent)
+}{m: make(map[uintptr]func(ChannelEvent))}
+
+func setChannelCallback(channelcontrol unsafe.Pointer, fn func(ChannelEvent)) {
+	channelCallbacks.Lock()
+	if fn != nil {
+		channelCallbacks.m[uintptr(channelcontrol)] = fn
+	} else {
+		delete(channelCallbacks.m, uintptr(channelcontrol))
+	}
+	channelCallbacks.Unlock()
+}
+
+//export goChannelControlCallback
+func goChannelControlCallback(channelcontrol *C.FMOD_CHANNELCONTROL, controltype C.FMOD_CHANNELCONTROL_TYPE, callbacktype C.FMOD_CHANNELCONTROL_CALLBACK_TYPE, commanddata1, commanddata2 unsafe.Pointer) C.FMOD_RESULT {
+	key := uintptr(unsafe.Pointer(channelcontrol))
+	ev := ChannelEvent{Type: ChannelControlCallbackType(callbacktype), ControlType: ChannelControlType(controltype)}
+
+	channelCallbacks.Lock()
+	fn, ok := channelCallbacks.m[key]
+	if ok && ev.Type == CHANNELCONTROL_CALLBACK_END && ev.ControlType == CHANNELCONTROL_CHANNEL {
+		// The channel handle is invalid once the sound has ended.
+		delete(channelCallbacks.m, key)
+	}
+	channelCallbacks.Unlock()
+	if !ok {
+		return C.FMOD_OK
+	}
+
+	if ev.ControlType == CHANNELCONTROL_CHANNEL {
+		ev.Channel = &Channel{cptr: (*C.FMOD_CHANNEL)(unsafe.Pointer(channelcontrol))}
+	} else {
+		ev.ChannelGroup = &ChannelGroup{cptr: (*C.FMOD_CHANNELGROUP)(unsafe.Pointer(channelcontrol))}
+	}
+	switch ev.Type {
+	case CHANNELCONTROL_CALLBACK_VIRTUALVOICE:
+		// The command data holds the value itself, not a pointer to it.
+		ev.Virtual = uintptr(commanddata1) != 0
+	case CHANNELCONTROL_CALLBACK_SYNCPOINT:
+		ev.SyncPoint = int(uintptr(commanddata1))
+	case CHANNELCONTROL_CALLBACK_OCCLUSION:
+		ev.Direct = (*float32)(commanddata1)
+		ev.Reverb = (*float32)(commanddata2)
+	}
+	fn(ev)
+	return C.FMOD_OK
+}
@@ -9,13 +9,13 @@ type ChannelController interface {
 	SystemObject() (*System, error)
 	Stop() error
 	Audibility() (float64, error)
-	SetCallback(callback C.FMOD_CHANNELCONTROL_CALLBACK) error
+	SetCallback(callback func(ev ChannelEvent)) error
 	IsPlaying() (bool, error)
 	SetPan(pan float64) error
 	SetMixLevelsOutput(frontleft, frontright, center, lfe, surroundleft, surroundright, backleft, backright float64) error
-	SetMixLevelsInput(levels *C.float, numlevels C.int) error
-	SetMixMatrix(matrix *C.float, outchannels, inchannels, inchannel_hop C.int) error
-	MixMatrix(matrix *C.float, outchannels, inchannels *C.int, inchannel_hop C.int) error
+	SetMixLevelsInput(levels []float32) error
+	SetMixMatrix(matrix *MixMatrix) error
+	MixMatrix() (*MixMatrix, error)
 
 	ThreeDimensionalAccessor
 	DSPAccessor
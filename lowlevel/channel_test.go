@@ -0,0 +1,159 @@
+package lowlevel
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestChannelEndCallback(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	censor, err := system.CreateSound("media/censor.wav", MODE_CREATESAMPLE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel, err := system.PlaySound(censor, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ended := make(chan ChannelEvent, 1)
+	err = channel.SetCallback(func(ev ChannelEvent) {
+		if ev.Type == CHANNELCONTROL_CALLBACK_END {
+			ended <- ev
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = channel.SetPaused(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Callbacks are raised from System.Update
+	timeout := time.After(5 * time.Second)
+	for {
+		err = system.Update()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case ev := <-ended:
+			if ev.ControlType != CHANNELCONTROL_CHANNEL || ev.Channel == nil {
+				t.Error("expected the end callback to be raised for a channel")
+			}
+			<-done
+			return
+		case <-timeout:
+			t.Fatal("expected an end callback")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestChannelMixMatrix(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	downmix, err := system.DownmixMatrix(SPEAKERMODE_5POINT1, SPEAKERMODE_STEREO)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if downmix.Out != 2 || downmix.In != 6 {
+		t.Errorf("expected a 2x6 downmix matrix but got %dx%d", downmix.Out, downmix.In)
+	}
+
+	err = system.ValidateMixMatrix(downmix, SPEAKERMODE_5POINT1, SPEAKERMODE_STEREO)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = system.UpmixMatrix(SPEAKERMODE_5POINT1, SPEAKERMODE_STEREO)
+	if err == nil {
+		t.Error("expected an error for an upmix to fewer channels")
+	}
+
+	censor, err := system.CreateSound("media/censor.wav", MODE_CREATESAMPLE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel, err := system.PlaySound(censor, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Isolate the left speaker.
+	isolate, err := MixMatrixFromRows([][]float32{{1}, {0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = channel.SetMixMatrix(isolate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matrix, err := channel.MixMatrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if matrix.Out < 2 || matrix.In < 1 || matrix.At(0, 0) != 1 || matrix.At(1, 0) != 0 {
+		t.Errorf("unexpected mix matrix %+v", matrix)
+	}
+
+	err = channel.SetMixMatrix(&MixMatrix{Out: 2, In: 2, Levels: []float32{1}})
+	if err == nil {
+		t.Error("expected an error for a matrix with missing levels")
+	}
+
+	err = channel.SetMixLevelsInput([]float32{0.5})
+	if err != nil {
+		t.Error(err)
+	}
+
+	<-done
+}
+
+func TestChannelGroupReleaseCallback(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := system.CreateChannelGroup("callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = group.SetCallback(func(ev ChannelEvent) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = group.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channelCallbacks.Lock()
+	_, ok := channelCallbacks.m[uintptr(unsafe.Pointer(group.cptr))]
+	channelCallbacks.Unlock()
+	if ok {
+		t.Error("expected the callback to be removed on release")
+	}
+
+	<-done
+}
@@ -7,6 +7,9 @@ import "C"
 
 const VERSION = C.FMOD_VERSION
 
+// Maximum number of channels, or speakers, in a signal. This is the largest size of a "MixMatrix".
+const MAX_CHANNEL_WIDTH = C.FMOD_MAX_CHANNEL_WIDTH
+
 // These callback types are used with System::setCallback.
 //
 // Each callback has commanddata parameters passed as void* unique to the type of callback.
@@ -65,8 +68,6 @@ const (
 // Structure describing a globally unique identifier.
 type Guid C.FMOD_GUID
 
-type CreatesSoundExInfo C.FMOD_CREATESOUNDEXINFO
-
 type SystemCallback C.FMOD_SYSTEM_CALLBACK
 
 type InitFlags C.FMOD_INITFLAGS
@@ -747,3 +748,113 @@ const (
 	CHANNELMASK_7POINT0       = C.FMOD_CHANNELMASK_7POINT0
 	CHANNELMASK_7POINT1       = C.FMOD_CHANNELMASK_7POINT1
 )
+
+// Used to distinguish if a channel control is a "Channel" or a "ChannelGroup" in a "ChannelEvent".
+type ChannelControlType C.FMOD_CHANNELCONTROL_TYPE
+
+const (
+	// The channel control is a "Channel".
+	CHANNELCONTROL_CHANNEL ChannelControlType = C.FMOD_CHANNELCONTROL_CHANNEL
+
+	// The channel control is a "ChannelGroup".
+	CHANNELCONTROL_CHANNELGROUP = C.FMOD_CHANNELCONTROL_CHANNELGROUP
+)
+
+// These callback types are used with "Channel.SetCallback" and "ChannelGroup.SetCallback".
+type ChannelControlCallbackType C.FMOD_CHANNELCONTROL_CALLBACK_TYPE
+
+const (
+	// Called when a sound ends.
+	CHANNELCONTROL_CALLBACK_END ChannelControlCallbackType = C.FMOD_CHANNELCONTROL_CALLBACK_END
+
+	// Called when a voice is swapped out or swapped in.
+	CHANNELCONTROL_CALLBACK_VIRTUALVOICE = C.FMOD_CHANNELCONTROL_CALLBACK_VIRTUALVOICE
+
+	// Called when a syncpoint is encountered. Can be from wav file markers.
+	CHANNELCONTROL_CALLBACK_SYNCPOINT = C.FMOD_CHANNELCONTROL_CALLBACK_SYNCPOINT
+
+	// Called when the channel has its geometry occlusion value calculated. Can be used to clamp or change the value.
+	CHANNELCONTROL_CALLBACK_OCCLUSION = C.FMOD_CHANNELCONTROL_CALLBACK_OCCLUSION
+)
+
+// Identifies the type of the object an error was raised for, see "ErrorInfo".
+type ErrorCallbackInstanceType C.FMOD_ERRORCALLBACK_INSTANCETYPE
+
+const (
+	ERRORCALLBACK_INSTANCETYPE_NONE                     ErrorCallbackInstanceType = C.FMOD_ERRORCALLBACK_INSTANCETYPE_NONE
+	ERRORCALLBACK_INSTANCETYPE_SYSTEM                                             = C.FMOD_ERRORCALLBACK_INSTANCETYPE_SYSTEM
+	ERRORCALLBACK_INSTANCETYPE_CHANNEL                                            = C.FMOD_ERRORCALLBACK_INSTANCETYPE_CHANNEL
+	ERRORCALLBACK_INSTANCETYPE_CHANNELGROUP                                       = C.FMOD_ERRORCALLBACK_INSTANCETYPE_CHANNELGROUP
+	ERRORCALLBACK_INSTANCETYPE_CHANNELCONTROL                                     = C.FMOD_ERRORCALLBACK_INSTANCETYPE_CHANNELCONTROL
+	ERRORCALLBACK_INSTANCETYPE_SOUND                                              = C.FMOD_ERRORCALLBACK_INSTANCETYPE_SOUND
+	ERRORCALLBACK_INSTANCETYPE_SOUNDGROUP                                         = C.FMOD_ERRORCALLBACK_INSTANCETYPE_SOUNDGROUP
+	ERRORCALLBACK_INSTANCETYPE_DSP                                                = C.FMOD_ERRORCALLBACK_INSTANCETYPE_DSP
+	ERRORCALLBACK_INSTANCETYPE_DSPCONNECTION                                      = C.FMOD_ERRORCALLBACK_INSTANCETYPE_DSPCONNECTION
+	ERRORCALLBACK_INSTANCETYPE_GEOMETRY                                           = C.FMOD_ERRORCALLBACK_INSTANCETYPE_GEOMETRY
+	ERRORCALLBACK_INSTANCETYPE_REVERB3D                                           = C.FMOD_ERRORCALLBACK_INSTANCETYPE_REVERB3D
+	ERRORCALLBACK_INSTANCETYPE_STUDIO_SYSTEM                                      = C.FMOD_ERRORCALLBACK_INSTANCETYPE_STUDIO_SYSTEM
+	ERRORCALLBACK_INSTANCETYPE_STUDIO_EVENTDESCRIPTION                            = C.FMOD_ERRORCALLBACK_INSTANCETYPE_STUDIO_EVENTDESCRIPTION
+	ERRORCALLBACK_INSTANCETYPE_STUDIO_EVENTINSTANCE                               = C.FMOD_ERRORCALLBACK_INSTANCETYPE_STUDIO_EVENTINSTANCE
+	ERRORCALLBACK_INSTANCETYPE_STUDIO_PARAMETERINSTANCE                           = C.FMOD_ERRORCALLBACK_INSTANCETYPE_STUDIO_PARAMETERINSTANCE
+	ERRORCALLBACK_INSTANCETYPE_STUDIO_BUS                                         = C.FMOD_ERRORCALLBACK_INSTANCETYPE_STUDIO_BUS
+	ERRORCALLBACK_INSTANCETYPE_STUDIO_VCA                                         = C.FMOD_ERRORCALLBACK_INSTANCETYPE_STUDIO_VCA
+	ERRORCALLBACK_INSTANCETYPE_STUDIO_BANK                                        = C.FMOD_ERRORCALLBACK_INSTANCETYPE_STUDIO_BANK
+	ERRORCALLBACK_INSTANCETYPE_STUDIO_COMMANDREPLAY                               = C.FMOD_ERRORCALLBACK_INSTANCETYPE_STUDIO_COMMANDREPLAY
+)
+
+// DSP parameter types, see "DSPParameterDesc".
+type DSPParameterType C.FMOD_DSP_PARAMETER_TYPE
+
+const (
+	// A floating point parameter.
+	DSP_PARAMETER_TYPE_FLOAT DSPParameterType = C.FMOD_DSP_PARAMETER_TYPE_FLOAT
+
+	// An integer parameter.
+	DSP_PARAMETER_TYPE_INT = C.FMOD_DSP_PARAMETER_TYPE_INT
+
+	// A boolean parameter.
+	DSP_PARAMETER_TYPE_BOOL = C.FMOD_DSP_PARAMETER_TYPE_BOOL
+
+	// A binary data parameter.
+	DSP_PARAMETER_TYPE_DATA = C.FMOD_DSP_PARAMETER_TYPE_DATA
+)
+
+// Flags that provide additional information about a particular driver, see "RecordDriver".
+type DriverState C.FMOD_DRIVER_STATE
+
+const (
+	// Device is currently plugged in.
+	DRIVER_STATE_CONNECTED DriverState = C.FMOD_DRIVER_STATE_CONNECTED
+
+	// Device is the users preferred choice.
+	DRIVER_STATE_DEFAULT = C.FMOD_DRIVER_STATE_DEFAULT
+)
+
+// These values describe what state a sound is in after MODE_NONBLOCKING has been used to open it, see "Sound.OpenState".
+type OpenState C.FMOD_OPENSTATE
+
+const (
+	// Opened and ready to play.
+	OPENSTATE_READY OpenState = C.FMOD_OPENSTATE_READY
+
+	// Initial load in progress.
+	OPENSTATE_LOADING = C.FMOD_OPENSTATE_LOADING
+
+	// Failed to open - file not found, out of memory etc. See the error of the "SoundFuture" or "CreatesSoundExInfo" NonBlockCallback.
+	OPENSTATE_ERROR = C.FMOD_OPENSTATE_ERROR
+
+	// Connecting to remote host (internet sounds only).
+	OPENSTATE_CONNECTING = C.FMOD_OPENSTATE_CONNECTING
+
+	// Buffering data.
+	OPENSTATE_BUFFERING = C.FMOD_OPENSTATE_BUFFERING
+
+	// Seeking to subsound and re-flushing stream buffer.
+	OPENSTATE_SEEKING = C.FMOD_OPENSTATE_SEEKING
+
+	// Ready and playing, but not possible to release at this time without stalling the main thread.
+	OPENSTATE_PLAYING = C.FMOD_OPENSTATE_PLAYING
+
+	// Seeking within a stream to a different position.
+	OPENSTATE_SETPOSITION = C.FMOD_OPENSTATE_SETPOSITION
+)
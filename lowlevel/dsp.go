@@ -1,10 +1,14 @@
 package lowlevel
 
 /*
+#include <stdlib.h>
 #include <fmod.h>
 */
 import "C"
-import "unsafe"
+import (
+	"runtime"
+	"unsafe"
+)
 
 type DSP struct {
 	cptr *C.FMOD_DSP
@@ -20,6 +24,7 @@ type DSP struct {
 // NOTE: If DSP is not removed from the Channel, ChannelGroup or System object with "Channel.RemoveDSP" or "ChannelGroup.RemoveDSP",
 // after being added with "Channel.AddDSP" or "ChannelGroup.AddDSP", it will not release and will instead return FMOD_ERR_DSP_INUSE.
 func (d *DSP) Release() error {
+	runtime.SetFinalizer(d, nil)
 	res := C.FMOD_DSP_Release(d.cptr)
 	return errs[res]
 }
@@ -311,44 +316,77 @@ func (d *DSP) SetParameterBool(index int, value bool) error {
 	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Sets a DSP unit's binary data parameter by index. To find out the parameter names and range, see the see also field.
+//
+// index: Parameter index for this unit. Find the number of parameters with "DSP.NumParameters".
+//
+// data: Data to be passed to the DSP unit. It is copied for the duration of the call.
+//
 // The parameter properties (such as min/max values) can be retrieved with "DSP.ParameterInfo".
-func (d *DSP) SetParameterData(index C.int, data *interface{}, length C.uint) error {
-	//FMOD_RESULT F_API FMOD_DSP_SetParameterData(FMOD_DSP *dsp, int index, void *data, unsigned int length);
-	return ErrNoImpl
+func (d *DSP) SetParameterData(index int, data []byte) error {
+	var cdata unsafe.Pointer
+	if len(data) > 0 {
+		cdata = C.CBytes(data)
+		defer C.free(cdata)
+	}
+	res := C.FMOD_DSP_SetParameterData(d.cptr, C.int(index), cdata, C.uint(len(data)))
+	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieves a DSP unit's floating point parameter by index. To find out the parameter names and range, see the see also field.
+//
+// index: Parameter index for this unit. Find the number of parameters with "DSP.NumParameters".
+//
+// Returns the value and its string representation, as formatted by the DSP unit.
 // The parameter properties (such as min/max values) can be retrieved with "DSP.ParameterInfo".
-func (d *DSP) ParameterFloat(index C.int, value *C.float, valuestr *C.char, valuestrlen C.int) error {
-	//FMOD_RESULT F_API FMOD_DSP_GetParameterFloat(FMOD_DSP *dsp, int index, float *value, char *valuestr, int valuestrlen);
-	return ErrNoImpl
+func (d *DSP) ParameterFloat(index int) (float64, string, error) {
+	var value C.float
+	var valuestr [C.FMOD_DSP_GETPARAM_VALUESTR_LENGTH]C.char
+	res := C.FMOD_DSP_GetParameterFloat(d.cptr, C.int(index), &value, &valuestr[0], C.FMOD_DSP_GETPARAM_VALUESTR_LENGTH)
+	return float64(value), C.GoString(&valuestr[0]), errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieves a DSP unit's integer parameter by index. To find out the parameter names and range, see the see also field.
+//
+// index: Parameter index for this unit. Find the number of parameters with "DSP.NumParameters".
+//
+// Returns the value and its string representation, as formatted by the DSP unit.
 // The parameter properties (such as min/max values) can be retrieved with "DSP.ParameterInfo".
-func (d *DSP) ParameterInt(index C.int, value *C.int, valuestr *C.char, valuestrlen C.int) error {
-	//FMOD_RESULT F_API FMOD_DSP_GetParameterInt(FMOD_DSP *dsp, int index, int *value, char *valuestr, int valuestrlen);
-	return ErrNoImpl
+func (d *DSP) ParameterInt(index int) (int, string, error) {
+	var value C.int
+	var valuestr [C.FMOD_DSP_GETPARAM_VALUESTR_LENGTH]C.char
+	res := C.FMOD_DSP_GetParameterInt(d.cptr, C.int(index), &value, &valuestr[0], C.FMOD_DSP_GETPARAM_VALUESTR_LENGTH)
+	return int(value), C.GoString(&valuestr[0]), errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieves a DSP unit's boolean parameter by index. To find out the parameter names and range, see the see also field.
+//
+// index: Parameter index for this unit. Find the number of parameters with "DSP.NumParameters".
+//
+// Returns the value and its string representation, as formatted by the DSP unit.
 // The parameter properties (such as min/max values) can be retrieved with "DSP.ParameterInfo".
-func (d *DSP) ParameterBool(index C.int, value *C.FMOD_BOOL, valuestr *C.char, valuestrlen C.int) error {
-	//FMOD_RESULT F_API FMOD_DSP_GetParameterBool(FMOD_DSP *dsp, int index, FMOD_BOOL *value, char *valuestr, int valuestrlen);
-	return ErrNoImpl
+func (d *DSP) ParameterBool(index int) (bool, string, error) {
+	var value C.FMOD_BOOL
+	var valuestr [C.FMOD_DSP_GETPARAM_VALUESTR_LENGTH]C.char
+	res := C.FMOD_DSP_GetParameterBool(d.cptr, C.int(index), &value, &valuestr[0], C.FMOD_DSP_GETPARAM_VALUESTR_LENGTH)
+	return setBool(value), C.GoString(&valuestr[0]), errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieves a DSP unit's data block parameter by index. To find out the parameter names and range, see the see also field.
+//
+// index: Parameter index for this unit. Find the number of parameters with "DSP.NumParameters".
+//
+// Returns a copy of the data and its string representation, as formatted by the DSP unit.
 // The parameter properties (such as min/max values) can be retrieved with "DSP.ParameterInfo".
-func (d *DSP) ParameterData(index C.int, data **interface{}, length *C.uint, valuestr *C.char, valuestrlen C.int) error {
-	//FMOD_RESULT F_API FMOD_DSP_GetParameterData(FMOD_DSP *dsp, int index, void **data, unsigned int *length, char *valuestr, int valuestrlen);
-	return ErrNoImpl
+func (d *DSP) ParameterData(index int) ([]byte, string, error) {
+	var data unsafe.Pointer
+	var length C.uint
+	var valuestr [C.FMOD_DSP_GETPARAM_VALUESTR_LENGTH]C.char
+	res := C.FMOD_DSP_GetParameterData(d.cptr, C.int(index), &data, &length, &valuestr[0], C.FMOD_DSP_GETPARAM_VALUESTR_LENGTH)
+	if res != C.FMOD_OK || data == nil {
+		return nil, C.GoString(&valuestr[0]), errs[res]
+	}
+	return C.GoBytes(data, C.int(length)), C.GoString(&valuestr[0]), errs[res]
 }
 
 // Retrieves the number of parameters a DSP unit has to control its behaviour.
@@ -359,12 +397,28 @@ func (d *DSP) NumParameters() (int, error) {
 	return int(numparams), errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieve information about a specified parameter within the DSP unit.
+//
+// index: Parameter index for this unit. Find the number of parameters with "DSP.NumParameters".
+//
 // Use "DSP.NumParameters" to find out the number of parameters for this DSP unit.
-func (d *DSP) ParameterInfo(index C.int, desc **C.FMOD_DSP_PARAMETER_DESC) error {
-	//FMOD_RESULT F_API FMOD_DSP_GetParameterInfo             (FMOD_DSP *dsp, int index, FMOD_DSP_PARAMETER_DESC **desc);
-	return ErrNoImpl
+func (d *DSP) ParameterInfo(index int) (DSPParameterDesc, error) {
+	var cdesc *C.FMOD_DSP_PARAMETER_DESC
+	var desc DSPParameterDesc
+	res := C.FMOD_DSP_GetParameterInfo(d.cptr, C.int(index), &cdesc)
+	if res == C.FMOD_OK && cdesc != nil {
+		desc.fromC(cdesc)
+	}
+	return desc, errs[res]
+}
+
+// Retrieves the Go processor of a unit created with "System.CreateDSP", or nil for other units.
+// Use it to reach the state of a processor, for example to read meters it computes.
+func (d *DSP) Processor() DSPProcessor {
+	if inst := lookupDSP(unsafe.Pointer(d.cptr)); inst != nil {
+		return inst.processor
+	}
+	return nil
 }
 
 // NOTE: Not implement yet
@@ -0,0 +1,302 @@
+package lowlevel
+
+/*
+#include <stdlib.h>
+#include <fmod.h>
+*/
+import "C"
+import (
+	"strconv"
+	"sync"
+	"unsafe"
+)
+
+// dspInstance is the Go side of a DSP unit created from a "DSPDesc".
+type dspInstance struct {
+	mu        sync.Mutex
+	processor DSPProcessor
+	params    []DSPParameterDesc
+	values    []interface{}
+
+	// C copies of data parameters, handed to FMOD by the get data callback.
+	data []unsafe.Pointer
+}
+
+// Units created from a "DSPDesc", keyed by DSP pointer.
+var dspInstances = struct {
+	sync.Mutex
+	m map[uintptr]*dspInstance
+}{m: make(map[uintptr]*dspInstance)}
+
+func lookupDSP(key unsafe.Pointer) *dspInstance {
+	dspInstances.Lock()
+	defer dspInstances.Unlock()
+	return dspInstances.m[uintptr(key)]
+}
+
+func (d *dspInstance) set(index C.int, value interface{}) C.FMOD_RESULT {
+	i := int(index)
+	if i < 0 || i >= len(d.params) {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	d.mu.Lock()
+	d.values[i] = value
+	d.mu.Unlock()
+	if setter, ok := d.processor.(DSPParameterSetter); ok {
+		setter.SetParameter(i, value)
+	}
+	return C.FMOD_OK
+}
+
+func (d *dspInstance) get(index C.int) (interface{}, bool) {
+	i := int(index)
+	if i < 0 || i >= len(d.params) {
+		return nil, false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.values[i], true
+}
+
+// writeValueStr copies s into the value string buffer FMOD passes to the get parameter callbacks, if any.
+func writeValueStr(valuestr *C.char, s string) {
+	if valuestr == nil {
+		return
+	}
+	copyCString(unsafe.Slice(valuestr, C.FMOD_DSP_GETPARAM_VALUESTR_LENGTH), s)
+}
+
+//export goDSPCreate
+func goDSPCreate(state *C.FMOD_DSP_STATE) C.FMOD_RESULT {
+	var userdata unsafe.Pointer
+	res := C.FMOD_DSP_GetUserData((*C.FMOD_DSP)(state.instance), &userdata)
+	if res != C.FMOD_OK {
+		return res
+	}
+	dspDescs.Lock()
+	desc := dspDescs.m[uintptr(userdata)]
+	dspDescs.Unlock()
+	if desc == nil || desc.New == nil {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+
+	d := &dspInstance{
+		processor: desc.New(),
+		params:    desc.Parameters,
+		values:    make([]interface{}, len(desc.Parameters)),
+		data:      make([]unsafe.Pointer, len(desc.Parameters)),
+	}
+	dspInstances.Lock()
+	dspInstances.m[uintptr(state.instance)] = d
+	dspInstances.Unlock()
+	for i := range d.params {
+		d.set(C.int(i), d.params[i].defaultValue())
+	}
+	return C.FMOD_OK
+}
+
+//export goDSPRelease
+func goDSPRelease(state *C.FMOD_DSP_STATE) C.FMOD_RESULT {
+	dspInstances.Lock()
+	d, ok := dspInstances.m[uintptr(state.instance)]
+	delete(dspInstances.m, uintptr(state.instance))
+	dspInstances.Unlock()
+	if ok {
+		for _, data := range d.data {
+			C.free(data)
+		}
+	}
+	return C.FMOD_OK
+}
+
+//export goDSPReset
+func goDSPReset(state *C.FMOD_DSP_STATE) C.FMOD_RESULT {
+	if d := lookupDSP(state.instance); d != nil {
+		d.processor.Reset()
+	}
+	return C.FMOD_OK
+}
+
+//export goDSPSetPosition
+func goDSPSetPosition(state *C.FMOD_DSP_STATE, pos C.uint) C.FMOD_RESULT {
+	if d := lookupDSP(state.instance); d != nil {
+		d.processor.SetPosition(uint32(pos))
+	}
+	return C.FMOD_OK
+}
+
+//export goDSPProcess
+func goDSPProcess(state *C.FMOD_DSP_STATE, length C.uint, inbufferarray, outbufferarray *C.FMOD_DSP_BUFFER_ARRAY, inputsidle C.FMOD_BOOL, op C.FMOD_DSP_PROCESS_OPERATION) C.FMOD_RESULT {
+	d := lookupDSP(state.instance)
+	if d == nil {
+		return C.FMOD_ERR_DSP_DONTPROCESS
+	}
+	format, _ := d.processor.(DSPFormatProcessor)
+
+	var inchannels int
+	if inbufferarray != nil && inbufferarray.numbuffers > 0 {
+		inchannels = int(*inbufferarray.buffernumchannels)
+	}
+
+	if op == C.FMOD_DSP_PROCESS_QUERY {
+		// Tell FMOD the output format, which is the input format unless the processor changes it.
+		if outbufferarray != nil && outbufferarray.numbuffers > 0 {
+			if format != nil {
+				*outbufferarray.buffernumchannels = C.int(format.OutputChannels(inchannels))
+				*outbufferarray.bufferchannelmask = 0
+			} else if inchannels > 0 {
+				*outbufferarray.buffernumchannels = *inbufferarray.buffernumchannels
+				*outbufferarray.bufferchannelmask = *inbufferarray.bufferchannelmask
+				outbufferarray.speakermode = inbufferarray.speakermode
+			}
+		}
+		var outchannels int
+		if outbufferarray != nil && outbufferarray.numbuffers > 0 {
+			outchannels = int(*outbufferarray.buffernumchannels)
+		}
+		if !d.processor.ShouldIProcess(setBool(inputsidle), int(length), outchannels) {
+			return C.FMOD_ERR_DSP_DONTPROCESS
+		}
+		return C.FMOD_OK
+	}
+
+	if outbufferarray == nil || outbufferarray.numbuffers == 0 {
+		return C.FMOD_OK
+	}
+	outchannels := int(*outbufferarray.buffernumchannels)
+	out := unsafe.Slice((*float32)(unsafe.Pointer(*outbufferarray.buffers)), int(length)*outchannels)
+	var in []float32
+	if inchannels > 0 && *inbufferarray.buffers != nil {
+		in = unsafe.Slice((*float32)(unsafe.Pointer(*inbufferarray.buffers)), int(length)*inchannels)
+	}
+	if format != nil {
+		format.Process(in, inchannels, out, outchannels)
+	} else {
+		d.processor.Read(in, out, outchannels)
+	}
+	return C.FMOD_OK
+}
+
+//export goDSPSetParamFloat
+func goDSPSetParamFloat(state *C.FMOD_DSP_STATE, index C.int, value C.float) C.FMOD_RESULT {
+	d := lookupDSP(state.instance)
+	if d == nil {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	return d.set(index, float64(value))
+}
+
+//export goDSPSetParamInt
+func goDSPSetParamInt(state *C.FMOD_DSP_STATE, index, value C.int) C.FMOD_RESULT {
+	d := lookupDSP(state.instance)
+	if d == nil {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	return d.set(index, int(value))
+}
+
+//export goDSPSetParamBool
+func goDSPSetParamBool(state *C.FMOD_DSP_STATE, index C.int, value C.FMOD_BOOL) C.FMOD_RESULT {
+	d := lookupDSP(state.instance)
+	if d == nil {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	return d.set(index, setBool(value))
+}
+
+//export goDSPSetParamData
+func goDSPSetParamData(state *C.FMOD_DSP_STATE, index C.int, data unsafe.Pointer, length C.uint) C.FMOD_RESULT {
+	d := lookupDSP(state.instance)
+	if d == nil {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	return d.set(index, C.GoBytes(data, C.int(length)))
+}
+
+//export goDSPGetParamFloat
+func goDSPGetParamFloat(state *C.FMOD_DSP_STATE, index C.int, value *C.float, valuestr *C.char) C.FMOD_RESULT {
+	d := lookupDSP(state.instance)
+	if d == nil {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	v, ok := d.get(index)
+	f, isFloat := v.(float64)
+	if !ok || !isFloat {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	*value = C.float(f)
+	writeValueStr(valuestr, strconv.FormatFloat(f, 'f', 2, 64))
+	return C.FMOD_OK
+}
+
+//export goDSPGetParamInt
+func goDSPGetParamInt(state *C.FMOD_DSP_STATE, index C.int, value *C.int, valuestr *C.char) C.FMOD_RESULT {
+	d := lookupDSP(state.instance)
+	if d == nil {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	v, ok := d.get(index)
+	i, isInt := v.(int)
+	if !ok || !isInt {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	*value = C.int(i)
+	desc := d.params[index].Int
+	if n := i - desc.Min; n >= 0 && n < len(desc.ValueNames) && len(desc.ValueNames) == desc.Max-desc.Min+1 {
+		writeValueStr(valuestr, desc.ValueNames[n])
+	} else {
+		writeValueStr(valuestr, strconv.Itoa(i))
+	}
+	return C.FMOD_OK
+}
+
+//export goDSPGetParamBool
+func goDSPGetParamBool(state *C.FMOD_DSP_STATE, index C.int, value *C.FMOD_BOOL, valuestr *C.char) C.FMOD_RESULT {
+	d := lookupDSP(state.instance)
+	if d == nil {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	v, ok := d.get(index)
+	b, isBool := v.(bool)
+	if !ok || !isBool {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	*value = getBool(b)
+	names := d.params[index].Bool.ValueNames
+	switch {
+	case len(names) == 2 && b:
+		writeValueStr(valuestr, names[1])
+	case len(names) == 2:
+		writeValueStr(valuestr, names[0])
+	case b:
+		writeValueStr(valuestr, "On")
+	default:
+		writeValueStr(valuestr, "Off")
+	}
+	return C.FMOD_OK
+}
+
+//export goDSPGetParamData
+func goDSPGetParamData(state *C.FMOD_DSP_STATE, index C.int, data *unsafe.Pointer, length *C.uint, valuestr *C.char) C.FMOD_RESULT {
+	d := lookupDSP(state.instance)
+	if d == nil {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	v, ok := d.get(index)
+	b, isData := v.([]byte)
+	if !ok || !isData {
+		return C.FMOD_ERR_INVALID_PARAM
+	}
+	// The data must stay valid after the callback returns, so it is copied to C memory owned by the unit.
+	d.mu.Lock()
+	C.free(d.data[index])
+	d.data[index] = nil
+	if len(b) > 0 {
+		d.data[index] = C.CBytes(b)
+	}
+	*data = d.data[index]
+	d.mu.Unlock()
+	*length = C.uint(len(b))
+	writeValueStr(valuestr, "")
+	return C.FMOD_OK
+}
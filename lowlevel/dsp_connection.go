@@ -53,28 +53,22 @@ func (d *DspConnection) Mix() (float64, error) {
 	return float64(volume), errs[res]
 }
 
-// NOTE: Not implement yet
 // Sets a NxN panning matrix on a DSP connection.
-// Skipping/hop is supported, so memory for the matrix can be wider than the width of the inchannels parameter.
 //
-// matrix: Pointer to an array of floating point matrix data, where rows represent output speakers, and columns represent input channels.
-//
-// outchannels: Number of output channels in the matrix being specified.
-//
-// inchannels: Number of input channels in the matrix being specified.
-//
-// inchannel_hop: Number of floating point values stored in memory for a row, so that the memory can be skipped through correctly to read the right values,
-// if the intended matrix memory to be read from is wider than the matrix stored in the DSPConnection.
-func (d *DspConnection) SetMixMatrix(matrix *C.float, outchannels, inchannels, inchannel_hop C.int) error {
-	//FMOD_RESULT F_API FMOD_DSPConnection_SetMixMatrix       (FMOD_DSPCONNECTION *dspconnection, float *matrix, int outchannels, int inchannels, int inchannel_hop);
-	return ErrNoImpl
+// matrix: The "MixMatrix" to apply, where rows represent output speakers, and columns represent input channels.
+func (d *DspConnection) SetMixMatrix(matrix *MixMatrix) error {
+	if err := matrix.validate(); err != nil {
+		return err
+	}
+	res := C.FMOD_DSPConnection_SetMixMatrix(d.cptr, matrix.levels(), C.int(matrix.Out), C.int(matrix.In), C.int(matrix.In))
+	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Returns the panning matrix set by the user, for a connection.
-func (d *DspConnection) MixMatrix(matrix *C.float, outchannels, inchannels, inchannel_hop *C.int) error {
-	//FMOD_RESULT F_API FMOD_DSPConnection_GetMixMatrix       (FMOD_DSPCONNECTION *dspconnection, float *matrix, int *outchannels, int *inchannels, int inchannel_hop);
-	return ErrNoImpl
+func (d *DspConnection) MixMatrix() (*MixMatrix, error) {
+	return getMixMatrix(func(matrix *C.float, outchannels, inchannels *C.int, inchannel_hop C.int) C.FMOD_RESULT {
+		return C.FMOD_DSPConnection_GetMixMatrix(d.cptr, matrix, outchannels, inchannels, inchannel_hop)
+	})
 }
 
 // Returns the type of the connection between 2 DSP units.
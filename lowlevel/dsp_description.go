@@ -1,105 +1,387 @@
 package lowlevel
 
 /*
-#include <fmod_common.h>
+#include <stdlib.h>
+#include <fmod.h>
+
+extern FMOD_RESULT goDSPCreate(FMOD_DSP_STATE *dsp_state);
+extern FMOD_RESULT goDSPRelease(FMOD_DSP_STATE *dsp_state);
+extern FMOD_RESULT goDSPReset(FMOD_DSP_STATE *dsp_state);
+extern FMOD_RESULT goDSPProcess(FMOD_DSP_STATE *dsp_state, unsigned int length, FMOD_DSP_BUFFER_ARRAY *inbufferarray, FMOD_DSP_BUFFER_ARRAY *outbufferarray, FMOD_BOOL inputsidle, FMOD_DSP_PROCESS_OPERATION op);
+extern FMOD_RESULT goDSPSetPosition(FMOD_DSP_STATE *dsp_state, unsigned int pos);
+extern FMOD_RESULT goDSPSetParamFloat(FMOD_DSP_STATE *dsp_state, int index, float value);
+extern FMOD_RESULT goDSPSetParamInt(FMOD_DSP_STATE *dsp_state, int index, int value);
+extern FMOD_RESULT goDSPSetParamBool(FMOD_DSP_STATE *dsp_state, int index, FMOD_BOOL value);
+extern FMOD_RESULT goDSPSetParamData(FMOD_DSP_STATE *dsp_state, int index, void *data, unsigned int length);
+extern FMOD_RESULT goDSPGetParamFloat(FMOD_DSP_STATE *dsp_state, int index, float *value, char *valuestr);
+extern FMOD_RESULT goDSPGetParamInt(FMOD_DSP_STATE *dsp_state, int index, int *value, char *valuestr);
+extern FMOD_RESULT goDSPGetParamBool(FMOD_DSP_STATE *dsp_state, int index, FMOD_BOOL *value, char *valuestr);
+extern FMOD_RESULT goDSPGetParamData(FMOD_DSP_STATE *dsp_state, int index, void **data, unsigned int *length, char *valuestr);
+
+// The process callback is used for every processor, as it covers both "DSPProcessor" and "DSPFormatProcessor".
+static void setDSPCallbacks(FMOD_DSP_DESCRIPTION *d) {
+	d->pluginsdkversion = FMOD_PLUGIN_SDK_VERSION;
+	d->create = (FMOD_DSP_CREATE_CALLBACK)goDSPCreate;
+	d->release = (FMOD_DSP_RELEASE_CALLBACK)goDSPRelease;
+	d->reset = (FMOD_DSP_RESET_CALLBACK)goDSPReset;
+	d->process = (FMOD_DSP_PROCESS_CALLBACK)goDSPProcess;
+	d->setposition = (FMOD_DSP_SETPOSITION_CALLBACK)goDSPSetPosition;
+	d->setparameterfloat = (FMOD_DSP_SETPARAM_FLOAT_CALLBACK)goDSPSetParamFloat;
+	d->setparameterint = (FMOD_DSP_SETPARAM_INT_CALLBACK)goDSPSetParamInt;
+	d->setparameterbool = (FMOD_DSP_SETPARAM_BOOL_CALLBACK)goDSPSetParamBool;
+	d->setparameterdata = (FMOD_DSP_SETPARAM_DATA_CALLBACK)goDSPSetParamData;
+	d->getparameterfloat = (FMOD_DSP_GETPARAM_FLOAT_CALLBACK)goDSPGetParamFloat;
+	d->getparameterint = (FMOD_DSP_GETPARAM_INT_CALLBACK)goDSPGetParamInt;
+	d->getparameterbool = (FMOD_DSP_GETPARAM_BOOL_CALLBACK)goDSPGetParamBool;
+	d->getparameterdata = (FMOD_DSP_GETPARAM_DATA_CALLBACK)goDSPGetParamData;
+}
+
+// The parameter descriptions are unions, which cgo cannot access directly.
+static void setFloatDesc(FMOD_DSP_PARAMETER_DESC *p, float min, float max, float defaultval) {
+	p->type = FMOD_DSP_PARAMETER_TYPE_FLOAT;
+	p->floatdesc.min = min;
+	p->floatdesc.max = max;
+	p->floatdesc.defaultval = defaultval;
+	p->floatdesc.mapping.type = FMOD_DSP_PARAMETER_FLOAT_MAPPING_TYPE_LINEAR;
+}
+
+static void setIntDesc(FMOD_DSP_PARAMETER_DESC *p, int min, int max, int defaultval, FMOD_BOOL goestoinf, char **valuenames) {
+	p->type = FMOD_DSP_PARAMETER_TYPE_INT;
+	p->intdesc.min = min;
+	p->intdesc.max = max;
+	p->intdesc.defaultval = defaultval;
+	p->intdesc.goestoinf = goestoinf;
+	p->intdesc.valuenames = (const char* const*)valuenames;
+}
+
+static void setBoolDesc(FMOD_DSP_PARAMETER_DESC *p, FMOD_BOOL defaultval, char **valuenames) {
+	p->type = FMOD_DSP_PARAMETER_TYPE_BOOL;
+	p->booldesc.defaultval = defaultval;
+	p->booldesc.valuenames = (const char* const*)valuenames;
+}
+
+static void setDataDesc(FMOD_DSP_PARAMETER_DESC *p, int datatype) {
+	p->type = FMOD_DSP_PARAMETER_TYPE_DATA;
+	p->datadesc.datatype = datatype;
+}
+
+static FMOD_DSP_PARAMETER_DESC_FLOAT floatDesc(FMOD_DSP_PARAMETER_DESC *p) { return p->floatdesc; }
+static FMOD_DSP_PARAMETER_DESC_INT intDesc(FMOD_DSP_PARAMETER_DESC *p) { return p->intdesc; }
+static FMOD_DSP_PARAMETER_DESC_BOOL boolDesc(FMOD_DSP_PARAMETER_DESC *p) { return p->booldesc; }
+static FMOD_DSP_PARAMETER_DESC_DATA dataDesc(FMOD_DSP_PARAMETER_DESC *p) { return p->datadesc; }
+static const char *valueName(const char* const* names, int index) { return names[index]; }
 */
 import "C"
+import (
+	"sync"
+	"unsafe"
+)
 
-// not gophered yet!
-type DSPDesc C.FMOD_DSP_DESCRIPTION
+// Implemented by DSP effects written in Go, and created with "System.CreateDSP".
+// The methods are called from FMOD's mixer thread, so they must not block or allocate much.
+type DSPProcessor interface {
+	// Processes length frames of interleaved samples from in into out.
+	// Both buffers hold length * channels samples. For units without inputs, in is nil.
+	Read(in, out []float32, channels int)
 
-// When creating a DSP unit, declare one of these and provide the relevant callbacks and name for FMOD to use when it creates and uses a DSP unit of this type.
-//
-// Members marked with [r] mean the variable is modified by FMOD and is for reading purposes only. Do not change this value.
-// Members marked with [w] mean the variable can be written to. The user can set the value.
+	// Resets any history buffers, for example when the unit is reused.
+	// Use to avoid clicks or artifacts.
+	Reset()
+
+	// Called when the unit should update its position, for example to reset a cursor when a generator is seeked.
+	SetPosition(pos uint32)
+
+	// Called before processing. Return false to skip processing, for example when inputs are idle and no effect tail is left.
+	// Use a count down to let effect tails play out before idling!
+	ShouldIProcess(inputsIdle bool, length, channels int) bool
+}
+
+// Implemented by DSP effects that change the channel format between input and output.
+// When a processor implements it, Process is used instead of "DSPProcessor.Read".
+type DSPFormatProcessor interface {
+	DSPProcessor
+
+	// Returns the number of output channels for the given number of input channels.
+	OutputChannels(inchannels int) int
+
+	// Processes length frames of interleaved samples from in into out, where each buffer has its own channel count.
+	Process(in []float32, inchannels int, out []float32, outchannels int)
+}
+
+// Implemented by DSP effects with parameters, to be told when a parameter changes.
+// value is a float64, an int, a bool or a []byte, depending on the "DSPParameterDesc" Type of the parameter.
+// It is called from the thread that set the parameter, so the processor must synchronize with its processing methods.
+type DSPParameterSetter interface {
+	SetParameter(index int, value interface{})
+}
+
+// When creating a DSP unit, declare one of these and provide the relevant processor and name for FMOD to use when it creates and uses a DSP unit of this type.
 //
 // There are 2 different ways to change a parameter in this architecture.
 // One is to use "DSP.SetParameterFloat" / "DSP.SetParameterInt" / "DSP.SetParameterBool" / "DSP.SetParameterData".
 // This is platform independant and is dynamic, so new unknown plugins can have their parameters enumerated and used.
 // The other is to use "DSP.ShowConfigDialog". This is platform specific and requires a GUI, and will display a dialog box to configure the plugin.
-/*
 type DSPDesc struct {
+	// The identifier of the DSP. This will also be used as the name of DSP and shouldn't change between versions. At most 31 characters.
+	Name string
 
-	// [w] The plugin SDK version this plugin is built for.  set to this to "PLUGIN_SDK_VERSION" defined above.
-	Pluginsdkversion uint
+	// Plugin writer's version number.
+	Version uint32
 
-	// [w] The identifier of the DSP. This will also be used as the name of DSP and shouldn't change between versions.
-	Name string //char  name[32];
+	// Number of input buffers to process. Use 0 for DSPs that only generate sound and 1 for effects that process incoming sound.
+	// The processors of generators are passed a nil input buffer, see "DSPProcessor.Read".
+	NumInputBuffers int
 
-	// [w] Plugin writer's version number.
-	Version uint
+	// Number of audio output buffers. Only one output buffer is currently supported.
+	NumOutputBuffers int
 
-	// [w] Number of input buffers to process.  Use 0 for DSPs that only generate sound and 1 for effects that process incoming sound.
-	Numinputbuffers int
+	// Parameters of the unit. The user finds them with "DSP.NumParameters" and "DSP.ParameterInfo".
+	Parameters []DSPParameterDesc
 
-	// [w] Number of audio output buffers.  Only one output buffer is currently supported.
-	Numoutputbuffers int
+	// Creates the processor of a new unit. It is called once for every unit created with this description.
+	New func() DSPProcessor
 
-	// [w] Create callback.  This is called when DSP unit is created.  Can be null.
-	Create C.FMOD_DSP_CREATE_CALLBACK
+	id    uintptr
+	cdesc *C.FMOD_DSP_DESCRIPTION
+}
 
-	// [w] Release callback.  This is called just before the unit is freed so the user can do any cleanup needed for the unit.  Can be null.
-	Release C.FMOD_DSP_RELEASE_CALLBACK
+// Descriptions passed to "System.CreateDSP", keyed by the id stored in the C description's userdata.
+// The C descriptions are referenced by FMOD for the lifetime of the units, so they are never freed.
+var dspDescs = struct {
+	sync.Mutex
+	m      map[uintptr]*DSPDesc
+	nextID uintptr
+}{m: make(map[uintptr]*DSPDesc)}
 
-	// [w] Reset callback.  This is called by the user to reset any history buffers that may need resetting for a filter, when it is to be used or re-used for the first time to its initial clean state.
-	// Use to avoid clicks or artifacts.
-	Reset C.FMOD_DSP_RESET_CALLBACK
+// toC builds the C description once and registers d, so units created from it can find their processor.
+func (d *DSPDesc) toC() *C.FMOD_DSP_DESCRIPTION {
+	dspDescs.Lock()
+	defer dspDescs.Unlock()
+	if d.cdesc != nil {
+		return d.cdesc
+	}
+	dspDescs.nextID++
+	d.id = dspDescs.nextID
+	dspDescs.m[d.id] = d
 
-	// [w] Read callback.  Processing is done here.  Can be null.
-	Read C.FMOD_DSP_READ_CALLBACK
+	cdesc := (*C.FMOD_DSP_DESCRIPTION)(C.calloc(1, C.sizeof_FMOD_DSP_DESCRIPTION))
+	copyCString(cdesc.name[:], d.Name)
+	cdesc.version = C.uint(d.Version)
+	cdesc.numinputbuffers = C.int(d.NumInputBuffers)
+	cdesc.numoutputbuffers = C.int(d.NumOutputBuffers)
+	if len(d.Parameters) > 0 {
+		params := (**C.FMOD_DSP_PARAMETER_DESC)(C.calloc(C.size_t(len(d.Parameters)), C.size_t(unsafe.Sizeof(uintptr(0)))))
+		cparams := unsafe.Slice(params, len(d.Parameters))
+		for i := range d.Parameters {
+			cparams[i] = d.Parameters[i].toC()
+		}
+		cdesc.numparameters = C.int(len(d.Parameters))
+		cdesc.paramdesc = params
+	}
+	*(*uintptr)(unsafe.Pointer(&cdesc.userdata)) = d.id
+	C.setDSPCallbacks(cdesc)
+	d.cdesc = cdesc
+	return cdesc
+}
 
-	// [w] Process callback.  Can be specified instead of the read callback if any channel format changes occur between input and output.
-	// This also replaces shouldiprocess and should return an error if the effect is to be bypassed.  Can be null.
-	Process C.FMOD_DSP_PROCESS_CALLBACK
+// fromC reads a C description owned by FMOD, such as the description of a plugin. New is left nil.
+func (d *DSPDesc) fromC(cdesc *C.FMOD_DSP_DESCRIPTION) {
+	d.Name = C.GoString(&cdesc.name[0])
+	d.Version = uint32(cdesc.version)
+	d.NumInputBuffers = int(cdesc.numinputbuffers)
+	d.NumOutputBuffers = int(cdesc.numoutputbuffers)
+	d.Parameters = nil
+	if cdesc.numparameters > 0 && cdesc.paramdesc != nil {
+		for _, cp := range unsafe.Slice(cdesc.paramdesc, int(cdesc.numparameters)) {
+			var p DSPParameterDesc
+			p.fromC(cp)
+			d.Parameters = append(d.Parameters, p)
+		}
+	}
+}
+
+// copyCString copies s into a fixed size C char array, truncating it if needed.
+func copyCString(dst []C.char, s string) {
+	n := len(s)
+	if n > len(dst)-1 {
+		n = len(dst) - 1
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = C.char(s[i])
+	}
+	dst[n] = 0
+}
+
+// cStrings allocates a C array of C strings. It is used for parameter value names, which live as long as their description.
+func cStrings(s []string) **C.char {
+	if len(s) == 0 {
+		return nil
+	}
+	array := (**C.char)(C.calloc(C.size_t(len(s)), C.size_t(unsafe.Sizeof(uintptr(0)))))
+	carray := unsafe.Slice(array, len(s))
+	for i := range s {
+		carray[i] = C.CString(s[i])
+	}
+	return array
+}
+
+/*
+   Parameter descriptions.
+*/
 
-	// [w] Set position callback.  This is called if the unit wants to update its position info but not process data, or
-	// reset a cursor position internally if it is reading data from a certain source.  Can be null.
-	Setposition C.FMOD_DSP_SETPOSITION_CALLBACK
+// Describes a float parameter, see "DSPParameterDesc".
+type DSPParameterFloat struct {
+	// Minimum parameter value.
+	Min float64
 
-	// [w] Number of parameters used in this filter.  The user finds this with "DSP.NumParameters"
-	Numparameters int
+	// Maximum parameter value.
+	Max float64
 
-	// [w] Variable number of parameter structures.
-	Paramdesc C.FMOD_DSP_PARAMETER_DESC
+	// Default parameter value.
+	Default float64
+}
 
-	// [w] This is called when the user calls "DSP.SetParameterFloat". Can be null.
-	Setparameterfloat C.FMOD_DSP_SETPARAM_FLOAT_CALLBACK
+// Describes an integer parameter, see "DSPParameterDesc".
+type DSPParameterInt struct {
+	// Minimum parameter value.
+	Min int
 
-	// [w] This is called when the user calls "DSP.SetParameterInt".   Can be null.
-	Setparameterint C.FMOD_DSP_SETPARAM_INT_CALLBACK
+	// Maximum parameter value.
+	Max int
 
-	// [w] This is called when the user calls "DSP.SetParameterBool".  Can be null.
-	Setparameterbool C.FMOD_DSP_SETPARAM_BOOL_CALLBACK
+	// Default parameter value.
+	Default int
 
-	// [w] This is called when the user calls "DSP.SetParameterData".  Can be null.
-	Setparameterdata C.FMOD_DSP_SETPARAM_DATA_CALLBACK
+	// Whether the last value represents infinity.
+	GoesToInf bool
 
-	// [w] This is called when the user calls "DSP.ParameterFloat". Can be null.
-	Getparameterfloat C.FMOD_DSP_GETPARAM_FLOAT_CALLBACK
+	// Names for each value, from Min to Max. Optional, and ignored unless there is exactly one name per value.
+	ValueNames []string
+}
 
-	// [w] This is called when the user calls "DSP.ParameterInt".   Can be null.
-	Getparameterint C.FMOD_DSP_GETPARAM_INT_CALLBACK
+// Describes a boolean parameter, see "DSPParameterDesc".
+type DSPParameterBool struct {
+	// Default parameter value.
+	Default bool
 
-	// [w] This is called when the user calls "DSP.ParameterBool".  Can be null.
-	Getparameterbool C.FMOD_DSP_GETPARAM_BOOL_CALLBACK
+	// Names for false and true. Optional, and ignored unless both are given.
+	ValueNames []string
+}
 
-	// [w] This is called when the user calls "DSP.ParameterData".  Can be null.
-	Getparameterdata C.FMOD_DSP_GETPARAM_DATA_CALLBACK
+// Describes a data parameter, see "DSPParameterDesc".
+type DSPParameterData struct {
+	// The type of data for this parameter. Use 0 or above for custom types, or one of the FMOD_DSP_PARAMETER_DATA_TYPE values.
+	DataType int
+}
 
-	// [w] This is called before processing.  You can detect if inputs are idle and return FMOD_OK to process, or any other error code to avoid processing the effect.
-	// Use a count down timer to allow effect tails to process before idling!
-	Shouldiprocess C.FMOD_DSP_SHOULDIPROCESS_CALLBACK
+// Describes one parameter of a DSP unit.
+// Use "NewDSPParameterFloat", "NewDSPParameterInt", "NewDSPParameterBool" or "NewDSPParameterData" to create one.
+type DSPParameterDesc struct {
+	// Type of this parameter. Only the field matching it is used.
+	Type DSPParameterType
 
-	// [w] Optional. Specify 0 to ignore. This is user data to be attached to the DSP unit during creation.  Access via "DSP.UserData".
-	Userdata *interface{} //void *userdata
+	// Name of the parameter to be displayed (ie "Cutoff frequency"). At most 15 characters.
+	Name string
 
-	// [w] Register callback.  This is called when DSP unit is loaded/registered.  Useful for 'global'/per system object init for plugin.  Can be null.
-	Sys_register C.FMOD_DSP_SYSTEM_REGISTER_CALLBACK
+	// Short string to be put next to value to denote the unit type (ie "hz"). At most 15 characters.
+	Label string
 
-	// [w] Deregister callback.  This is called when DSP unit is unloaded/deregistered.  Useful as 'global'/per system object shutdown for plugin.  Can be null.
-	Sys_deregister C.FMOD_DSP_SYSTEM_DEREGISTER_CALLBACK
+	// Description of the parameter to be displayed as a help item / tooltip for this parameter.
+	Description string
 
-	// [w] System mix stage callback.  This is called when the mixer starts to execute or is just finishing executing.  Useful for 'global'/per system object once a mix update calls for a plugin.  Can be null.
-	Sys_mix C.FMOD_DSP_SYSTEM_MIX_CALLBACK
+	Float DSPParameterFloat
+	Int   DSPParameterInt
+	Bool  DSPParameterBool
+	Data  DSPParameterData
+}
+
+// Describes a float parameter ranging from min to max.
+func NewDSPParameterFloat(name, label, description string, min, max, defaultval float64) DSPParameterDesc {
+	return DSPParameterDesc{Type: DSP_PARAMETER_TYPE_FLOAT, Name: name, Label: label, Description: description,
+		Float: DSPParameterFloat{Min: min, Max: max, Default: defaultval}}
+}
+
+// Describes an integer parameter ranging from min to max. valuenames is optional.
+func NewDSPParameterInt(name, label, description string, min, max, defaultval int, goestoinf bool, valuenames ...string) DSPParameterDesc {
+	return DSPParameterDesc{Type: DSP_PARAMETER_TYPE_INT, Name: name, Label: label, Description: description,
+		Int: DSPParameterInt{Min: min, Max: max, Default: defaultval, GoesToInf: goestoinf, ValueNames: valuenames}}
+}
+
+// Describes a boolean parameter. valuenames is optional, and holds the names of false and true.
+func NewDSPParameterBool(name, label, description string, defaultval bool, valuenames ...string) DSPParameterDesc {
+	return DSPParameterDesc{Type: DSP_PARAMETER_TYPE_BOOL, Name: name, Label: label, Description: description,
+		Bool: DSPParameterBool{Default: defaultval, ValueNames: valuenames}}
+}
+
+// Describes a data parameter of the given data type.
+func NewDSPParameterData(name, label, description string, datatype int) DSPParameterDesc {
+	return DSPParameterDesc{Type: DSP_PARAMETER_TYPE_DATA, Name: name, Label: label, Description: description,
+		Data: DSPParameterData{DataType: datatype}}
+}
+
+// defaultValue returns the initial value of the parameter, as passed to "DSPParameterSetter".
+func (p *DSPParameterDesc) defaultValue() interface{} {
+	switch p.Type {
+	case DSP_PARAMETER_TYPE_FLOAT:
+		return p.Float.Default
+	case DSP_PARAMETER_TYPE_INT:
+		return p.Int.Default
+	case DSP_PARAMETER_TYPE_BOOL:
+		return p.Bool.Default
+	}
+	return []byte(nil)
+}
+
+func (p *DSPParameterDesc) toC() *C.FMOD_DSP_PARAMETER_DESC {
+	cp := (*C.FMOD_DSP_PARAMETER_DESC)(C.calloc(1, C.sizeof_FMOD_DSP_PARAMETER_DESC))
+	copyCString(cp.name[:], p.Name)
+	copyCString(cp.label[:], p.Label)
+	cp.description = C.CString(p.Description)
+	switch p.Type {
+	case DSP_PARAMETER_TYPE_FLOAT:
+		C.setFloatDesc(cp, C.float(p.Float.Min), C.float(p.Float.Max), C.float(p.Float.Default))
+	case DSP_PARAMETER_TYPE_INT:
+		var names **C.char
+		if len(p.Int.ValueNames) == p.Int.Max-p.Int.Min+1 {
+			names = cStrings(p.Int.ValueNames)
+		}
+		C.setIntDesc(cp, C.int(p.Int.Min), C.int(p.Int.Max), C.int(p.Int.Default), getBool(p.Int.GoesToInf), names)
+	case DSP_PARAMETER_TYPE_BOOL:
+		var names **C.char
+		if len(p.Bool.ValueNames) == 2 {
+			names = cStrings(p.Bool.ValueNames)
+		}
+		C.setBoolDesc(cp, getBool(p.Bool.Default), names)
+	case DSP_PARAMETER_TYPE_DATA:
+		C.setDataDesc(cp, C.int(p.Data.DataType))
+	}
+	return cp
+}
+
+func (p *DSPParameterDesc) fromC(cp *C.FMOD_DSP_PARAMETER_DESC) {
+	p.Type = DSPParameterType(cp._type)
+	p.Name = C.GoString(&cp.name[0])
+	p.Label = C.GoString(&cp.label[0])
+	p.Description = C.GoString(cp.description)
+	switch p.Type {
+	case DSP_PARAMETER_TYPE_FLOAT:
+		f := C.floatDesc(cp)
+		p.Float = DSPParameterFloat{Min: float64(f.min), Max: float64(f.max), Default: float64(f.defaultval)}
+	case DSP_PARAMETER_TYPE_INT:
+		i := C.intDesc(cp)
+		p.Int = DSPParameterInt{Min: int(i.min), Max: int(i.max), Default: int(i.defaultval), GoesToInf: setBool(i.goestoinf)}
+		if i.valuenames != nil {
+			for v := 0; v <= p.Int.Max-p.Int.Min; v++ {
+				p.Int.ValueNames = append(p.Int.ValueNames, C.GoString(C.valueName(i.valuenames, C.int(v))))
+			}
+		}
+	case DSP_PARAMETER_TYPE_BOOL:
+		b := C.boolDesc(cp)
+		p.Bool = DSPParameterBool{Default: setBool(b.defaultval)}
+		if b.valuenames != nil {
+			p.Bool.ValueNames = []string{C.GoString(C.valueName(b.valuenames, 0)), C.GoString(C.valueName(b.valuenames, 1))}
+		}
+	case DSP_PARAMETER_TYPE_DATA:
+		p.Data = DSPParameterData{DataType: int(C.dataDesc(cp).datatype)}
+	}
 }
-*/
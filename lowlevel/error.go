@@ -9,6 +9,16 @@ import "errors"
 var ErrNoImpl = errors.New("Not implement yet")
 var errs map[C.FMOD_RESULT]error
 
+// ResultErrors returns a copy of the FMOD_RESULT to error mapping used by this package.
+// Packages built on top of lowlevel (such as studio) use it so the same error values are returned by both APIs.
+func ResultErrors() map[int]error {
+	m := make(map[int]error, len(errs))
+	for res, err := range errs {
+		m[int(res)] = err
+	}
+	return m
+}
+
 func init() {
 	errs = map[C.FMOD_RESULT]error{
 		C.FMOD_OK:                            nil,
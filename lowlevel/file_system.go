@@ -0,0 +1,378 @@
+package lowlevel
+
+/*
+#include <fmod.h>
+*/
+import "C"
+import (
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// Identifies a file operation reported to the logger set with "System.AttachFileSystem".
+type FileOp int
+
+const (
+	// A file was opened. FileAccess.Size is the size of the file.
+	FILE_OPEN FileOp = iota
+
+	// A file was closed.
+	FILE_CLOSE
+
+	// Data was read from a file. FileAccess.Size is the number of bytes read.
+	FILE_READ
+
+	// A file was seeked. FileAccess.Position is the new position.
+	FILE_SEEK
+)
+
+// Describes a file access made by FMOD, see "System.AttachFileSystem".
+type FileAccess struct {
+	// The operation.
+	Op FileOp
+
+	// The name the file was opened with.
+	Name string
+
+	// File size for "FILE_OPEN", number of bytes read for "FILE_READ".
+	Size int
+
+	// Seek position for "FILE_SEEK", in bytes.
+	Position int
+}
+
+// fsFile is a file opened through the fs.FS set with "System.SetFS".
+type fsFile struct {
+	mu   sync.Mutex
+	name string
+	file fs.File
+	pos  int64
+}
+
+// readAt reads len(p) bytes at offset from the file, whatever the interfaces it implements.
+func (f *fsFile) readAt(p []byte, offset int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if ra, ok := f.file.(io.ReaderAt); ok {
+		return ra.ReadAt(p, offset)
+	}
+	if err := f.seek(offset); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(f.file, p)
+	f.pos += int64(n)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// seek moves the read position of files implementing io.Seeker, or reopens and skips forward otherwise.
+func (f *fsFile) seek(offset int64) error {
+	if offset == f.pos {
+		return nil
+	}
+	if s, ok := f.file.(io.Seeker); ok {
+		pos, err := s.Seek(offset, io.SeekStart)
+		f.pos = pos
+		return err
+	}
+	if offset < f.pos {
+		fileSystem.Lock()
+		fsys := fileSystem.fsys
+		fileSystem.Unlock()
+		if fsys == nil {
+			return fs.ErrClosed
+		}
+		file, err := fsys.Open(f.name)
+		if err != nil {
+			return err
+		}
+		f.file.Close()
+		f.file, f.pos = file, 0
+	}
+	n, err := io.CopyN(io.Discard, f.file, offset-f.pos)
+	f.pos += n
+	return err
+}
+
+// FMOD does not tell the file callbacks which System they are called for, so the file system is process wide.
+var fileSystem = struct {
+	sync.Mutex
+	fsys   fs.FS
+	logger func(FileAccess)
+	files  map[uintptr]*fsFile
+	names  map[uintptr]string
+	nextID uintptr
+}{
+	files: make(map[uintptr]*fsFile),
+	names: make(map[uintptr]string),
+}
+
+func lookupFile(handle unsafe.Pointer) *fsFile {
+	fileSystem.Lock()
+	defer fileSystem.Unlock()
+	return fileSystem.files[uintptr(handle)]
+}
+
+// fsName converts a name passed to "System.CreateSound" into an fs.FS path.
+func fsName(name string) string {
+	return strings.TrimPrefix(path.Clean(name), "/")
+}
+
+//export goFileOpen
+func goFileOpen(name *C.char, filesize *C.uint, handle *unsafe.Pointer, userdata unsafe.Pointer) C.FMOD_RESULT {
+	fileSystem.Lock()
+	fsys := fileSystem.fsys
+	fileSystem.Unlock()
+	if fsys == nil {
+		return C.FMOD_ERR_FILE_NOTFOUND
+	}
+
+	f := &fsFile{name: fsName(C.GoString(name))}
+	// An empty name would open the root of the file system.
+	if f.name == "." {
+		return C.FMOD_ERR_FILE_NOTFOUND
+	}
+	file, err := fsys.Open(f.name)
+	if err != nil {
+		return C.FMOD_ERR_FILE_NOTFOUND
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return C.FMOD_ERR_FILE_BAD
+	}
+	f.file = file
+
+	fileSystem.Lock()
+	// Handles are registry keys rather than Go pointers, which must not be stored by C.
+	fileSystem.nextID++
+	id := fileSystem.nextID
+	fileSystem.files[id] = f
+	fileSystem.Unlock()
+
+	*filesize = C.uint(info.Size())
+	*(*uintptr)(unsafe.Pointer(handle)) = id
+	return C.FMOD_OK
+}
+
+//export goFileClose
+func goFileClose(handle, userdata unsafe.Pointer) C.FMOD_RESULT {
+	fileSystem.Lock()
+	f, ok := fileSystem.files[uintptr(handle)]
+	delete(fileSystem.files, uintptr(handle))
+	fileSystem.Unlock()
+	if !ok {
+		return C.FMOD_ERR_INVALID_HANDLE
+	}
+	f.file.Close()
+	return C.FMOD_OK
+}
+
+//export goFileRead
+func goFileRead(handle, buffer unsafe.Pointer, sizebytes C.uint, bytesread *C.uint, userdata unsafe.Pointer) C.FMOD_RESULT {
+	f := lookupFile(handle)
+	if f == nil {
+		return C.FMOD_ERR_INVALID_HANDLE
+	}
+	f.mu.Lock()
+	pos := f.pos
+	f.mu.Unlock()
+	n, err := f.readAt(unsafe.Slice((*byte)(buffer), int(sizebytes)), pos)
+	f.mu.Lock()
+	f.pos = pos + int64(n)
+	f.mu.Unlock()
+	*bytesread = C.uint(n)
+	return fileResult(n, int(sizebytes), err)
+}
+
+//export goFileSeek
+func goFileSeek(handle unsafe.Pointer, pos C.uint, userdata unsafe.Pointer) C.FMOD_RESULT {
+	f := lookupFile(handle)
+	if f == nil {
+		return C.FMOD_ERR_INVALID_HANDLE
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.file.(io.ReaderAt); ok {
+		// Reads go through ReadAt, only the position needs to be tracked.
+		f.pos = int64(pos)
+		return C.FMOD_OK
+	}
+	if err := f.seek(int64(pos)); err != nil {
+		return C.FMOD_ERR_FILE_COULDNOTSEEK
+	}
+	return C.FMOD_OK
+}
+
+// fileResult converts the outcome of a read into the result FMOD expects.
+func fileResult(n, size int, err error) C.FMOD_RESULT {
+	if n < size {
+		// FMOD expects EOF whenever less data than requested was read.
+		if err == nil || err == io.EOF {
+			return C.FMOD_ERR_FILE_EOF
+		}
+		return C.FMOD_ERR_FILE_BAD
+	}
+	return C.FMOD_OK
+}
+
+/*
+   Asynchronous reads.
+*/
+
+// asyncRead tracks an FMOD_ASYNCREADINFO request serviced by the worker pool of "System.SetFSAsync".
+type asyncRead struct {
+	info     *C.FMOD_ASYNCREADINFO
+	started  bool
+	canceled bool
+	done     chan struct{}
+}
+
+// asyncPool is the queue of a set of workers started by "System.SetFSAsync".
+type asyncPool struct {
+	queue chan *asyncRead
+	// Closed when the pool is replaced, so senders blocked on a full queue give up.
+	closing chan struct{}
+	// Senders which may still use queue. The queue is closed once they are gone.
+	senders sync.WaitGroup
+}
+
+var asyncReads = struct {
+	sync.Mutex
+	pool    *asyncPool
+	pending map[*C.FMOD_ASYNCREADINFO]*asyncRead
+}{pending: make(map[*C.FMOD_ASYNCREADINFO]*asyncRead)}
+
+// asyncWorker services queued reads until the queue is closed.
+func asyncWorker(queue chan *asyncRead) {
+	for r := range queue {
+		asyncReads.Lock()
+		canceled := r.canceled
+		r.started = true
+		asyncReads.Unlock()
+		result := C.FMOD_RESULT(C.FMOD_ERR_INVALID_HANDLE)
+		if !canceled {
+			if f := lookupFile(r.info.handle); f != nil {
+				size := int(r.info.sizebytes)
+				n, err := f.readAt(unsafe.Slice((*byte)(r.info.buffer), size), int64(r.info.offset))
+				r.info.bytesread = C.uint(n)
+				result = fileResult(n, size, err)
+			}
+		}
+		// FMOD may reuse info for a new request as soon as it is done, so the entry is dropped first,
+		// and only if it still belongs to this request.
+		asyncReads.Lock()
+		if asyncReads.pending[r.info] == r {
+			delete(asyncReads.pending, r.info)
+		}
+		asyncReads.Unlock()
+		if !canceled {
+			asyncReadDone(r.info, result)
+		}
+		close(r.done)
+	}
+}
+
+//export goFileAsyncRead
+func goFileAsyncRead(info *C.FMOD_ASYNCREADINFO, userdata unsafe.Pointer) C.FMOD_RESULT {
+	r := &asyncRead{info: info, done: make(chan struct{})}
+	asyncReads.Lock()
+	pool := asyncReads.pool
+	if pool == nil {
+		asyncReads.Unlock()
+		return C.FMOD_ERR_NOTREADY
+	}
+	asyncReads.pending[info] = r
+	pool.senders.Add(1)
+	asyncReads.Unlock()
+	defer pool.senders.Done()
+	// The queue is sized so that FMOD's thread rarely blocks here.
+	select {
+	case pool.queue <- r:
+		return C.FMOD_OK
+	case <-pool.closing:
+		asyncReads.Lock()
+		if asyncReads.pending[info] == r {
+			delete(asyncReads.pending, info)
+		}
+		asyncReads.Unlock()
+		return C.FMOD_ERR_FILE_DISKEJECTED
+	}
+}
+
+//export goFileAsyncCancel
+func goFileAsyncCancel(info *C.FMOD_ASYNCREADINFO, userdata unsafe.Pointer) C.FMOD_RESULT {
+	asyncReads.Lock()
+	r, ok := asyncReads.pending[info]
+	if !ok {
+		asyncReads.Unlock()
+		return C.FMOD_OK
+	}
+	if !r.started {
+		r.canceled = true
+		asyncReads.Unlock()
+		asyncReadDone(info, C.FMOD_ERR_FILE_DISKEJECTED)
+		return C.FMOD_OK
+	}
+	asyncReads.Unlock()
+	// FMOD must not reuse info until the read in progress has completed.
+	<-r.done
+	return C.FMOD_OK
+}
+
+/*
+   Attached file system.
+*/
+
+func logFileAccess(access FileAccess) {
+	fileSystem.Lock()
+	logger := fileSystem.logger
+	fileSystem.Unlock()
+	if logger != nil {
+		logger(access)
+	}
+}
+
+func attachedName(handle unsafe.Pointer) string {
+	fileSystem.Lock()
+	defer fileSystem.Unlock()
+	return fileSystem.names[uintptr(handle)]
+}
+
+//export goFileAttachOpen
+func goFileAttachOpen(name *C.char, filesize *C.uint, handle *unsafe.Pointer, userdata unsafe.Pointer) C.FMOD_RESULT {
+	access := FileAccess{Op: FILE_OPEN, Name: C.GoString(name), Size: int(*filesize)}
+	fileSystem.Lock()
+	fileSystem.names[uintptr(*handle)] = access.Name
+	fileSystem.Unlock()
+	logFileAccess(access)
+	return C.FMOD_OK
+}
+
+//export goFileAttachClose
+func goFileAttachClose(handle, userdata unsafe.Pointer) C.FMOD_RESULT {
+	access := FileAccess{Op: FILE_CLOSE, Name: attachedName(handle)}
+	fileSystem.Lock()
+	delete(fileSystem.names, uintptr(handle))
+	fileSystem.Unlock()
+	logFileAccess(access)
+	return C.FMOD_OK
+}
+
+//export goFileAttachRead
+func goFileAttachRead(handle, buffer unsafe.Pointer, sizebytes C.uint, bytesread *C.uint, userdata unsafe.Pointer) C.FMOD_RESULT {
+	logFileAccess(FileAccess{Op: FILE_READ, Name: attachedName(handle), Size: int(*bytesread)})
+	return C.FMOD_OK
+}
+
+//export goFileAttachSeek
+func goFileAttachSeek(handle unsafe.Pointer, pos C.uint, userdata unsafe.Pointer) C.FMOD_RESULT {
+	logFileAccess(FileAccess{Op: FILE_SEEK, Name: attachedName(handle), Position: int(pos)})
+	return C.FMOD_OK
+}
@@ -0,0 +1,186 @@
+package lowlevel
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+)
+
+// Common track information, gathered from the tags of a sound by "Sound.Metadata".
+type Metadata struct {
+	// Title of the track. For netstreams without a title tag, the stream title which usually is "Artist - Title".
+	Title string
+
+	// Artist of the track.
+	Artist string
+
+	// Album of the track.
+	Album string
+
+	// Embedded cover art image, or nil.
+	Cover []byte
+
+	// MIME type of the cover art, for example "image/jpeg".
+	CoverMIME string
+}
+
+// Retrieves the title, artist, album and cover art of the sound from its ID3v1, ID3v2, Vorbis comment, Shoutcast and Icecast tags.
+// See "MetadataFromTags".
+func (s *Sound) Metadata() (Metadata, error) {
+	tags, err := s.Tags()
+	if err != nil {
+		return Metadata{}, err
+	}
+	return MetadataFromTags(tags), nil
+}
+
+// Retrieves the track information if any tag changed since tags were last retrieved, for example when a netstream changes song.
+// The boolean reports whether anything changed. See "Sound.UpdatedTags".
+func (s *Sound) UpdatedMetadata() (Metadata, bool, error) {
+	updated, err := s.UpdatedTags()
+	if err != nil || len(updated) == 0 {
+		return Metadata{}, false, err
+	}
+	m, err := s.Metadata()
+	return m, err == nil, err
+}
+
+// Gathers common track information from a list of tags.
+// When a field is found in several tag formats, Vorbis comments and ID3v2 take precedence over ID3v1 and stream tags.
+func MetadataFromTags(tags []Tag) Metadata {
+	var m Metadata
+	// Lower ranks win.
+	var titleRank, artistRank, albumRank int
+	set := func(field *string, rank *int, value string, r int) {
+		if value != "" && (*field == "" || r < *rank) {
+			*field, *rank = value, r
+		}
+	}
+	for _, tag := range tags {
+		name := strings.ToUpper(tag.Name)
+		switch tag.Type {
+		case TAGTYPE_VORBISCOMMENT:
+			switch name {
+			case "TITLE":
+				set(&m.Title, &titleRank, tag.String(), 0)
+			case "ARTIST":
+				set(&m.Artist, &artistRank, tag.String(), 0)
+			case "ALBUM":
+				set(&m.Album, &albumRank, tag.String(), 0)
+			case "METADATA_BLOCK_PICTURE":
+				if m.Cover == nil {
+					m.CoverMIME, m.Cover = flacPicture(tag.String())
+				}
+			}
+		case TAGTYPE_ID3V2:
+			switch name {
+			case "TIT2", "TT2":
+				set(&m.Title, &titleRank, tag.String(), 0)
+			case "TPE1", "TP1":
+				set(&m.Artist, &artistRank, tag.String(), 0)
+			case "TALB", "TAL":
+				set(&m.Album, &albumRank, tag.String(), 0)
+			case "APIC", "PIC":
+				if data, ok := tag.Value.([]byte); ok && m.Cover == nil {
+					m.CoverMIME, m.Cover = id3Picture(data, name == "PIC")
+				}
+			}
+		case TAGTYPE_ID3V1:
+			switch name {
+			case "TITLE":
+				set(&m.Title, &titleRank, strings.TrimSpace(tag.String()), 1)
+			case "ARTIST":
+				set(&m.Artist, &artistRank, strings.TrimSpace(tag.String()), 1)
+			case "ALBUM":
+				set(&m.Album, &albumRank, strings.TrimSpace(tag.String()), 1)
+			}
+		case TAGTYPE_SHOUTCAST, TAGTYPE_ICECAST:
+			if name == "STREAMTITLE" || name == "TITLE" {
+				set(&m.Title, &titleRank, tag.String(), 2)
+			}
+		}
+	}
+	return m
+}
+
+// id3Picture extracts the image of an ID3v2 attached picture frame.
+// APIC frames hold a text encoding, a NUL terminated MIME type, a picture type, a description in the text encoding and the image.
+// ID3v2.2 PIC frames have a three character image format instead of the MIME type.
+func id3Picture(data []byte, v22 bool) (string, []byte) {
+	if len(data) < 2 {
+		return "", nil
+	}
+	encoding := data[0]
+	data = data[1:]
+	var mime string
+	if v22 {
+		if len(data) < 3 {
+			return "", nil
+		}
+		mime = "image/" + strings.ToLower(string(data[:3]))
+		data = data[3:]
+	} else {
+		end := bytes.IndexByte(data, 0)
+		if end < 0 {
+			return "", nil
+		}
+		mime = string(data[:end])
+		data = data[end+1:]
+	}
+	// Skip the picture type.
+	if len(data) < 1 {
+		return "", nil
+	}
+	data = data[1:]
+	// Skip the description, terminated by one NUL, or two for the UTF-16 encodings.
+	if encoding == 1 || encoding == 2 {
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 && data[i+1] == 0 {
+				return mime, data[i+2:]
+			}
+		}
+		return "", nil
+	}
+	end := bytes.IndexByte(data, 0)
+	if end < 0 {
+		return "", nil
+	}
+	return mime, data[end+1:]
+}
+
+// flacPicture extracts the image of a base64 encoded FLAC picture block, as stored in the METADATA_BLOCK_PICTURE Vorbis comment.
+func flacPicture(value string) (string, []byte) {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", nil
+	}
+	// Reads a big endian length prefixed field.
+	field := func() []byte {
+		if len(data) < 4 {
+			return nil
+		}
+		n := binary.BigEndian.Uint32(data)
+		data = data[4:]
+		if uint32(len(data)) < n {
+			data = nil
+			return nil
+		}
+		f := data[:n]
+		data = data[n:]
+		return f
+	}
+	// Skip the picture type.
+	if len(data) < 4 {
+		return "", nil
+	}
+	data = data[4:]
+	mime := string(field())
+	field()
+	// Skip the width, height, color depth and number of colors.
+	if len(data) < 16 {
+		return "", nil
+	}
+	data = data[16:]
+	return mime, field()
+}
@@ -0,0 +1,159 @@
+package lowlevel
+
+/*
+#include <fmod.h>
+*/
+import "C"
+import "unsafe"
+
+// A pan matrix that maps input channels (columns) to output speakers (rows).
+// Use it with "Channel.SetMixMatrix", "ChannelGroup.SetMixMatrix" and "DspConnection.SetMixMatrix".
+type MixMatrix struct {
+	// Number of output channels (rows), from 0 to MAX_CHANNEL_WIDTH inclusive.
+	Out int
+
+	// Number of input channels (columns), from 0 to MAX_CHANNEL_WIDTH inclusive.
+	In int
+
+	// Volume levels in row-major order. The gain for input channel 's' to output channel 't' is Levels[t * In + s].
+	// Levels can be below 0 to invert a signal and above 1 to amplify the signal.
+	Levels []float32
+}
+
+// Creates a silent matrix of out rows and in columns.
+func NewMixMatrix(out, in int) (*MixMatrix, error) {
+	if out < 0 || in < 0 || out > MAX_CHANNEL_WIDTH || in > MAX_CHANNEL_WIDTH {
+		return nil, errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	return &MixMatrix{Out: out, In: in, Levels: make([]float32, out*in)}, nil
+}
+
+// Creates a matrix from its rows. Each row represents an output speaker and must have one level per input channel.
+func MixMatrixFromRows(rows [][]float32) (*MixMatrix, error) {
+	in := 0
+	if len(rows) > 0 {
+		in = len(rows[0])
+	}
+	m, err := NewMixMatrix(len(rows), in)
+	if err != nil {
+		return nil, err
+	}
+	for t, row := range rows {
+		if len(row) != in {
+			return nil, errs[C.FMOD_ERR_INVALID_PARAM]
+		}
+		copy(m.Levels[t*in:], row)
+	}
+	return m, nil
+}
+
+// Returns the gain for input channel in to output channel out.
+func (m *MixMatrix) At(out, in int) float32 {
+	return m.Levels[out*m.In+in]
+}
+
+// Sets the gain for input channel in to output channel out.
+func (m *MixMatrix) Set(out, in int, level float32) {
+	m.Levels[out*m.In+in] = level
+}
+
+// Returns the rows of the matrix, one per output speaker. The rows share the memory of Levels.
+func (m *MixMatrix) Rows() [][]float32 {
+	rows := make([][]float32, m.Out)
+	for t := range rows {
+		rows[t] = m.Levels[t*m.In : (t+1)*m.In : (t+1)*m.In]
+	}
+	return rows
+}
+
+// validate checks the dimensions of the matrix against its levels.
+func (m *MixMatrix) validate() error {
+	if m == nil || m.Out < 0 || m.In < 0 || m.Out > MAX_CHANNEL_WIDTH || m.In > MAX_CHANNEL_WIDTH || len(m.Levels) != m.Out*m.In {
+		return errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	return nil
+}
+
+// levels returns the C view of the levels, or nil for an empty matrix.
+func (m *MixMatrix) levels() *C.float {
+	if len(m.Levels) == 0 {
+		return nil
+	}
+	return (*C.float)(unsafe.Pointer(&m.Levels[0]))
+}
+
+// getMixMatrix retrieves a matrix with get, which is called once to query its size and once to copy its levels.
+func getMixMatrix(get func(matrix *C.float, outchannels, inchannels *C.int, inchannel_hop C.int) C.FMOD_RESULT) (*MixMatrix, error) {
+	var outchannels, inchannels C.int
+	if res := get(nil, &outchannels, &inchannels, 0); res != C.FMOD_OK {
+		return nil, errs[res]
+	}
+	m, err := NewMixMatrix(int(outchannels), int(inchannels))
+	if err != nil || len(m.Levels) == 0 {
+		return m, err
+	}
+	res := get(m.levels(), &outchannels, &inchannels, inchannels)
+	return m, errs[res]
+}
+
+// Checks that a matrix converts from the channels of the source speaker mode to the channels of the target speaker mode,
+// as reported by "System.SpeakerModeChannels".
+func (s *System) ValidateMixMatrix(matrix *MixMatrix, sourcespeakermode, targetspeakermode SpeakerMode) error {
+	if err := matrix.validate(); err != nil {
+		return err
+	}
+	in, err := s.SpeakerModeChannels(sourcespeakermode)
+	if err != nil {
+		return err
+	}
+	out, err := s.SpeakerModeChannels(targetspeakermode)
+	if err != nil {
+		return err
+	}
+	if matrix.In != in || matrix.Out != out {
+		return errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	return nil
+}
+
+// Creates a matrix which passes each channel of a speaker mode through to the same speaker.
+func (s *System) IdentityMixMatrix(mode SpeakerMode) (*MixMatrix, error) {
+	channels, err := s.SpeakerModeChannels(mode)
+	if err != nil {
+		return nil, err
+	}
+	m, err := NewMixMatrix(channels, channels)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < channels; i++ {
+		m.Set(i, i, 1)
+	}
+	return m, nil
+}
+
+// Creates the default matrix folding a speaker mode down to a speaker mode with fewer channels, for example 5.1 to stereo.
+// Returns ERR_INVALID_PARAM if the target speaker mode has more channels than the source.
+func (s *System) DownmixMatrix(sourcespeakermode, targetspeakermode SpeakerMode) (*MixMatrix, error) {
+	m, err := s.DefaultMixMatrix(sourcespeakermode, targetspeakermode)
+	if err != nil {
+		return nil, err
+	}
+	if m.Out > m.In {
+		return nil, errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	return m, nil
+}
+
+// Creates the default matrix spreading a speaker mode over a speaker mode with more channels, for example stereo to 5.1.
+// Returns ERR_INVALID_PARAM if the target speaker mode has fewer channels than the source.
+func (s *System) UpmixMatrix(sourcespeakermode, targetspeakermode SpeakerMode) (*MixMatrix, error) {
+	m, err := s.DefaultMixMatrix(sourcespeakermode, targetspeakermode)
+	if err != nil {
+		return nil, err
+	}
+	if m.Out < m.In {
+		return nil, errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	return m, nil
+}
@@ -0,0 +1,19 @@
+package lowlevel
+
+// Identifies a plugin loaded into FMOD, either built in or loaded with "System.LoadPlugin".
+type PluginHandle uint32
+
+// Information to display for a plugin, returned by "System.PluginInfo" and "System.Plugins".
+type PluginInfo struct {
+	// Handle of the plugin.
+	Handle PluginHandle
+
+	// Type of the plugin, such as PLUGINTYPE_OUTPUT, PLUGINTYPE_CODEC or PLUGINTYPE_DSP.
+	Type PluginType
+
+	// Name of the plugin.
+	Name string
+
+	// Version of the plugin.
+	Version uint32
+}
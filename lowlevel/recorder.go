@@ -0,0 +1,225 @@
+package lowlevel
+
+/*
+#include <fmod.h>
+*/
+import "C"
+import (
+	"context"
+	"time"
+)
+
+// Interval between two record position queries made by "Recorder".
+const recordPollInterval = 10 * time.Millisecond
+
+// Records from a recording device into a looping ring buffer sound, and hands out the captured samples as Go slices.
+// The ring buffer is read as the record position moves, so the buffer must be long enough to cover the delay between two reads.
+//
+// Create one with "System.NewRecorder", then call "Recorder.StreamInt16" or "Recorder.StreamFloat32".
+type Recorder struct {
+	system *System
+	driver RecordDriver
+	sound  *Sound
+	format SoundFormat
+
+	// Length of the ring buffer, in PCM samples.
+	length uint32
+}
+
+// Creates a recorder for a recording device.
+// The ring buffer uses the rate and channel count of the device.
+//
+// id: Enumerated driver ID. This must be in a valid range delimited by "System.RecordNumDrivers".
+//
+// format: Format of the ring buffer, SOUND_FORMAT_PCM16 or SOUND_FORMAT_PCMFLOAT.
+//
+// buffer: Length of the ring buffer. Use 0 for one second.
+func (s *System) NewRecorder(id int, format SoundFormat, buffer time.Duration) (*Recorder, error) {
+	if format != SOUND_FORMAT_PCM16 && format != SOUND_FORMAT_PCMFLOAT {
+		return nil, errs[C.FMOD_ERR_FORMAT]
+	}
+	driver, err := s.RecordDriverInfo(id)
+	if err != nil {
+		return nil, err
+	}
+	if buffer <= 0 {
+		buffer = time.Second
+	}
+	length := uint32(buffer.Seconds() * float64(driver.SystemRate))
+	sound, err := s.createRecordSound(driver.SystemRate, driver.Channels, format, length)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{system: s, driver: driver, sound: sound, format: format, length: length}, nil
+}
+
+// createRecordSound creates a looping user sound to record to.
+func (s *System) createRecordSound(rate, channels int, format SoundFormat, length uint32) (*Sound, error) {
+	exinfo := &CreatesSoundExInfo{
+		Length:           length * uint32(channels*formatBytes(format)),
+		NumChannels:      channels,
+		DefaultFrequency: rate,
+		Format:           format,
+	}
+	return s.CreateSound("", MODE_2D|MODE_OPENUSER|MODE_LOOP_NORMAL, exinfo)
+}
+
+// Retrieves the recording device the recorder captures from.
+func (r *Recorder) Driver() RecordDriver {
+	return r.driver
+}
+
+// Retrieves the ring buffer sound the recorder captures into.
+// It can be played back with "System.PlaySound" to monitor the input.
+func (r *Recorder) Sound() *Sound {
+	return r.sound
+}
+
+// Retrieves the sample rate of the captured samples.
+func (r *Recorder) Rate() int {
+	return r.driver.SystemRate
+}
+
+// Retrieves the number of interleaved channels of the captured samples.
+func (r *Recorder) Channels() int {
+	return r.driver.Channels
+}
+
+// Stops recording and frees the ring buffer sound.
+func (r *Recorder) Release() error {
+	recording, err := r.system.IsRecording(r.driver.ID)
+	if err == nil && recording {
+		r.system.RecordStop(r.driver.ID)
+	}
+	return r.sound.Release()
+}
+
+// Starts recording and sends the captured samples on chunks, as interleaved 16 bit samples, until ctx is done or recording fails.
+// A SOUND_FORMAT_PCMFLOAT ring buffer is converted.
+// It blocks, and stops recording before returning ctx.Err() or the recording error.
+func (r *Recorder) StreamInt16(ctx context.Context, chunks chan<- []int16) error {
+	return r.stream(ctx, func(data []byte) bool {
+		select {
+		case chunks <- int16Samples(data, r.format):
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+// Starts recording and sends the captured samples on chunks, as interleaved float samples, until ctx is done or recording fails.
+// A SOUND_FORMAT_PCM16 ring buffer is converted.
+// It blocks, and stops recording before returning ctx.Err() or the recording error.
+func (r *Recorder) StreamFloat32(ctx context.Context, chunks chan<- []float32) error {
+	return r.stream(ctx, func(data []byte) bool {
+		select {
+		case chunks <- float32Samples(data, r.format):
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+// stream follows the record position and passes every newly recorded part of the ring buffer to send.
+func (r *Recorder) stream(ctx context.Context, send func(data []byte) bool) error {
+	err := r.system.RecordStart(r.driver.ID, r.sound, true)
+	if err != nil {
+		return err
+	}
+	defer r.system.RecordStop(r.driver.ID)
+
+	ticker := time.NewTicker(recordPollInterval)
+	defer ticker.Stop()
+	var last uint32
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		pos, err := r.system.RecordPosition(r.driver.ID)
+		if err != nil {
+			return err
+		}
+		if pos == last {
+			continue
+		}
+		data, err := r.read(last, ringDistance(last, pos, r.length))
+		if err != nil {
+			return err
+		}
+		last = pos
+		if !send(data) {
+			return ctx.Err()
+		}
+	}
+}
+
+// read copies length PCM samples from the ring buffer, starting at offset, wrapping around its end.
+func (r *Recorder) read(offset, length uint32) ([]byte, error) {
+	frame := uint32(r.driver.Channels * formatBytes(r.format))
+	lock, err := r.sound.Lock(offset*frame, length*frame)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 0, len(lock.Data1)+len(lock.Data2))
+	data = append(data, lock.Data1...)
+	data = append(data, lock.Data2...)
+	return data, lock.Unlock()
+}
+
+// ringDistance returns the number of PCM samples from one position to another of a ring buffer of length samples,
+// going forward and wrapping around its end.
+func ringDistance(from, to, length uint32) uint32 {
+	return (to + length - from) % length
+}
+
+// formatBytes returns the size of one sample of a PCM format, or 0 for other formats.
+func formatBytes(format SoundFormat) int {
+	switch format {
+	case SOUND_FORMAT_PCM8:
+		return 1
+	case SOUND_FORMAT_PCM16:
+		return 2
+	case SOUND_FORMAT_PCM24:
+		return 3
+	case SOUND_FORMAT_PCM32, SOUND_FORMAT_PCMFLOAT:
+		return 4
+	}
+	return 0
+}
+
+// int16Samples converts native endian PCM16 or PCMFLOAT samples to 16 bit samples.
+func int16Samples(data []byte, format SoundFormat) []int16 {
+	if format == SOUND_FORMAT_PCM16 {
+		return int16View(data)
+	}
+	in := float32View(data)
+	out := make([]int16, len(in))
+	for i, v := range in {
+		switch {
+		case v >= 1:
+			out[i] = 32767
+		case v <= -1:
+			out[i] = -32768
+		default:
+			out[i] = int16(v * 32768)
+		}
+	}
+	return out
+}
+
+// float32Samples converts native endian PCM16 or PCMFLOAT samples to float samples.
+func float32Samples(data []byte, format SoundFormat) []float32 {
+	if format == SOUND_FORMAT_PCMFLOAT {
+		return float32View(data)
+	}
+	in := int16View(data)
+	out := make([]float32, len(in))
+	for i, v := range in {
+		out[i] = float32(v) / 32768
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package lowlevel
+
+/*
+#include <fmod.h>
+*/
+import "C"
+import "unsafe"
+
+// Gives direct access to the sample data of a sound, returned by "Sound.Lock".
+// The slices point at FMOD's own memory, so writes to them change the sound.
+//
+// The slices are only valid until "SampleLock.Unlock" is called, which clears them.
+// Slices kept from before the unlock must not be used.
+type SampleLock struct {
+	// The locked data, up to the end of the sample buffer.
+	Data1 []byte
+
+	// The part of the locked data that wrapped around to the start of the sample buffer, or nil.
+	Data2 []byte
+
+	sound      *Sound
+	format     SoundFormat
+	ptr1, ptr2 unsafe.Pointer
+	len1, len2 C.uint
+}
+
+// Retrieves the format of the locked data, as returned by "Sound.Format".
+func (l *SampleLock) Format() SoundFormat {
+	return l.format
+}
+
+// Retrieves the locked regions as 16 bit samples.
+// Both are nil unless the format is SOUND_FORMAT_PCM16.
+func (l *SampleLock) Int16() ([]int16, []int16) {
+	if l.format != SOUND_FORMAT_PCM16 {
+		return nil, nil
+	}
+	return int16View(l.Data1), int16View(l.Data2)
+}
+
+// Retrieves the locked regions as float samples.
+// Both are nil unless the format is SOUND_FORMAT_PCMFLOAT.
+func (l *SampleLock) Float32() ([]float32, []float32) {
+	if l.format != SOUND_FORMAT_PCMFLOAT {
+		return nil, nil
+	}
+	return float32View(l.Data1), float32View(l.Data2)
+}
+
+// Releases the lock and clears the slices.
+// Unlocking an already unlocked SampleLock does nothing.
+func (l *SampleLock) Unlock() error {
+	if l.sound == nil {
+		return nil
+	}
+	res := C.FMOD_Sound_Unlock(l.sound.cptr, l.ptr1, l.ptr2, l.len1, l.len2)
+	l.Data1, l.Data2 = nil, nil
+	l.sound, l.ptr1, l.ptr2 = nil, nil, nil
+	return errs[res]
+}
+
+// int16View reinterprets native endian PCM16 data, without copying it.
+func int16View(data []byte) []int16 {
+	if len(data) < 2 {
+		return nil
+	}
+	return unsafe.Slice((*int16)(unsafe.Pointer(&data[0])), len(data)/2)
+}
+
+// float32View reinterprets native endian PCMFLOAT data, without copying it.
+func float32View(data []byte) []float32 {
+	if len(data) < 4 {
+		return nil
+	}
+	return unsafe.Slice((*float32)(unsafe.Pointer(&data[0])), len(data)/4)
+}
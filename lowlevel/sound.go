@@ -1,15 +1,85 @@
 package lowlevel
 
 /*
+#include <stdlib.h>
 #include <fmod.h>
 */
 import "C"
-import "unsafe"
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"unsafe"
+)
 
 type Sound struct {
 	cptr *C.FMOD_SOUND
 }
 
+// Wraps a raw FMOD_SOUND pointer which was obtained from another FMOD API, for example a Studio event callback.
+// The returned Sound does not own the object, so it has no finalizer attached.
+func SoundFromPointer(ptr unsafe.Pointer) *Sound {
+	return &Sound{cptr: (*C.FMOD_SOUND)(ptr)}
+}
+
+// Returns the raw FMOD_SOUND pointer, for passing the object to another FMOD API.
+func (s *Sound) Pointer() unsafe.Pointer {
+	return unsafe.Pointer(s.cptr)
+}
+
+// Memory handed to FMOD with MODE_OPENMEMORY_POINT by "System.CreateSoundFromMemory", keyed by sound pointer.
+var soundMemory = struct {
+	sync.Mutex
+	m map[uintptr]soundMemoryEntry
+}{m: make(map[uintptr]soundMemoryEntry)}
+
+type soundMemoryEntry struct {
+	// The System the sound was created on, which frees the sound when it is released.
+	system uintptr
+	data   unsafe.Pointer
+}
+
+func setSoundMemory(system *C.FMOD_SYSTEM, sound *C.FMOD_SOUND, data unsafe.Pointer) {
+	key := uintptr(unsafe.Pointer(sound))
+	soundMemory.Lock()
+	old, ok := soundMemory.m[key]
+	soundMemory.m[key] = soundMemoryEntry{system: uintptr(unsafe.Pointer(system)), data: data}
+	soundMemory.Unlock()
+	// FMOD only reuses the address of a sound which has been freed, so the memory of the previous one is no longer used.
+	if ok {
+		C.free(old.data)
+	}
+}
+
+// freeSoundMemory frees the memory of a released sound, if it has any.
+func freeSoundMemory(sound *C.FMOD_SOUND) {
+	key := uintptr(unsafe.Pointer(sound))
+	soundMemory.Lock()
+	entry, ok := soundMemory.m[key]
+	delete(soundMemory.m, key)
+	soundMemory.Unlock()
+	if ok {
+		C.free(entry.data)
+	}
+}
+
+// freeSystemSoundMemory frees the memory of the sounds of a released System, which have been released along with it.
+func freeSystemSoundMemory(system *C.FMOD_SYSTEM) {
+	key := uintptr(unsafe.Pointer(system))
+	var data []unsafe.Pointer
+	soundMemory.Lock()
+	for sound, entry := range soundMemory.m {
+		if entry.system == key {
+			data = append(data, entry.data)
+			delete(soundMemory.m, sound)
+		}
+	}
+	soundMemory.Unlock()
+	for _, d := range data {
+		C.free(d)
+	}
+}
+
 /*
    'Sound' API
 */
@@ -19,7 +89,12 @@ type Sound struct {
 // If this is a stream that is playing as a subsound of another parent stream, then if this is the currently playing subsound, the whole stream will stop.
 // Note - This function will block if it was opened with NONBLOCKING and hasn't finished opening yet.
 func (s *Sound) Release() error {
+	runtime.SetFinalizer(s, nil)
 	res := C.FMOD_Sound_Release(s.cptr)
+	if res == C.FMOD_OK {
+		freeSoundMemory(s.cptr)
+		removeUserSound(s.cptr)
+	}
 	return errs[res]
 }
 
@@ -34,26 +109,39 @@ func (s *Sound) SystemObject() (*System, error) {
    Standard sound manipulation functions.
 */
 
-// NOTE: Not implement yet
-// Returns a pointer to the beginning of the sample data for a sound.
+// Returns the sample data of a sound for direct access, without copying it.
 //
 // offset: Offset in bytes to the position you want to lock in the sample buffer.
 //
-//length: Number of bytes you want to lock in the sample buffer.
+// length: Number of bytes you want to lock in the sample buffer.
 //
-// You must always unlock the data again after you have finished with it, using Sound::unlock.
+// You must always unlock the data again after you have finished with it, using "SampleLock.Unlock".
 // With this function you get access to the RAW audio data, for example 8, 16, 24 or 32bit PCM data, mono or stereo data, and on consoles, vag, xadpcm or gcadpcm compressed data.
-// You must take this into consideration when processing the data within the pointer.
-func (s *Sound) Lock(offset, length C.uint, ptr1, ptr2 **interface{}, len1, len2 *C.uint) error {
-	//FMOD_RESULT F_API FMOD_Sound_Lock(FMOD_SOUND *sound, unsigned int offset, unsigned int length, void **ptr1, void **ptr2, unsigned int *len1, unsigned int *len2);
-	return ErrNoImpl
+// You must take this into consideration when processing the data within the slices, see "SampleLock.Format".
+//
+// If the locked range goes past the end of the sample buffer, it wraps around to the start, which is returned as the second region.
+func (s *Sound) Lock(offset, length uint32) (*SampleLock, error) {
+	_, format, _, _, err := s.Format()
+	if err != nil {
+		return nil, err
+	}
+	l := &SampleLock{sound: s, format: format}
+	res := C.FMOD_Sound_Lock(s.cptr, C.uint(offset), C.uint(length), &l.ptr1, &l.ptr2, &l.len1, &l.len2)
+	if res != C.FMOD_OK {
+		return nil, errs[res]
+	}
+	if l.len1 > 0 {
+		l.Data1 = unsafe.Slice((*byte)(l.ptr1), int(l.len1))
+	}
+	if l.len2 > 0 {
+		l.Data2 = unsafe.Slice((*byte)(l.ptr2), int(l.len2))
+	}
+	return l, nil
 }
 
-// NOTE: Not implement yet
-// Releases previous sample data lock from "Sound.Lock".
-func (s *Sound) Unlock(ptr1, ptr2 *interface{}, len1, len2 C.uint) error {
-	//FMOD_RESULT F_API FMOD_Sound_Unlock(FMOD_SOUND *sound, void *ptr1, void *ptr2, unsigned int len1, unsigned int len2);
-	return ErrNoImpl
+// Releases previous sample data lock from "Sound.Lock". It is the same as "SampleLock.Unlock".
+func (s *Sound) Unlock(lock *SampleLock) error {
+	return lock.Unlock()
 }
 
 // Sets a sounds's default attributes, so when it is played it uses these values without having to specify them later for each channel each time the sound is played.
@@ -244,30 +332,101 @@ func (s *Sound) NumTags() (int, int, error) {
 	return int(numtags), int(numtagsupdated), errs[res]
 }
 
-// NOTE: Not implement yet
-// TODO: add more docs
 // Retrieves a descriptive tag stored by the sound, to describe things like the song name, author etc.
-func (s *Sound) Tag(name *C.char, index C.int, tag *C.FMOD_TAG) error {
-	//FMOD_RESULT F_API FMOD_Sound_GetTag(FMOD_SOUND *sound, const char *name, int index, FMOD_TAG *tag);
-	return ErrNoImpl
+//
+// index: Index into the tag list, from 0 to the number of tags returned by "Sound.NumTags" - 1.
+//
+// The Updated flag of the tag is cleared by FMOD once the tag has been retrieved,
+// so it can be used to find out which tags changed, for example when a netstream changes song.
+func (s *Sound) Tag(index int) (Tag, error) {
+	var ctag C.FMOD_TAG
+	var tag Tag
+	res := C.FMOD_Sound_GetTag(s.cptr, nil, C.int(index), &ctag)
+	if res == C.FMOD_OK {
+		tag.fromC(ctag)
+	}
+	return tag, errs[res]
 }
 
-// NOTE: Not implement yet
-// Retrieves the state a sound is in after FMOD_NONBLOCKING has been used to open it, or the state of the streaming buffer.
-// When a sound is opened with FMOD_NONBLOCKING, it is opened and prepared in the background, or asynchronously.
+// Retrieves a descriptive tag stored by the sound, by name.
+//
+// name: Name of the tag, for example "TITLE" or "TIT2". Tag names are listed in the documentation of the respective tag formats.
+//
+// index: Index of the tag among the tags with this name, as a name can be used by several tags.
+func (s *Sound) TagByName(name string, index int) (Tag, error) {
+	var ctag C.FMOD_TAG
+	var tag Tag
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	res := C.FMOD_Sound_GetTag(s.cptr, cname, C.int(index), &ctag)
+	if res == C.FMOD_OK {
+		tag.fromC(ctag)
+	}
+	return tag, errs[res]
+}
+
+// Retrieves all tags of the sound, see "Sound.Tag".
+func (s *Sound) Tags() ([]Tag, error) {
+	numtags, _, err := s.NumTags()
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]Tag, numtags)
+	for i := range tags {
+		tags[i], err = s.Tag(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+// Retrieves the tags which were added or changed since they were last retrieved, for example the title of a netstream when the song changes.
+// Returns nil when nothing changed, so it can be polled cheaply, for example after each "System.Update".
+//
+// FMOD clears the Updated flag of a tag once it has been retrieved, so "Sound.Tag", "Sound.Tags" and "Sound.Metadata" also consume the changes.
+func (s *Sound) UpdatedTags() ([]Tag, error) {
+	numtags, numtagsupdated, err := s.NumTags()
+	if err != nil || numtagsupdated == 0 {
+		return nil, err
+	}
+	var updated []Tag
+	for i := 0; i < numtags; i++ {
+		tag, err := s.Tag(i)
+		if err != nil {
+			return nil, err
+		}
+		if tag.Updated {
+			updated = append(updated, tag)
+		}
+	}
+	return updated, nil
+}
+
+// Retrieves the state a sound is in after MODE_NONBLOCKING has been used to open it, or the state of the streaming buffer.
+// When a sound is opened with MODE_NONBLOCKING, it is opened and prepared in the background, or asynchronously.
 // This allows the main application to execute without stalling on audio loads.
 // This function will describe the state of the asynchronous load routine i.e. whether it has succeeded, failed or is still in progress.
 //
-// If 'starving' is true, then you will most likely hear a stuttering/repeating sound as the decode buffer loops on itself and replays old data.
-// Now that this variable exists, you can detect buffer underrun and use something like Channel::setMute to keep it quiet until it is not starving any more.
-//
-// Note: Always check 'openstate' to determine the state of the sound. Do not assume that if this function returns FMOD_OK then the sound has finished loading.
-func (s *Sound) OpenState(openstate *C.FMOD_OPENSTATE, percentbuffered *C.uint, starving, diskbusy *C.FMOD_BOOL) error {
-	//FMOD_RESULT F_API FMOD_Sound_GetOpenState(FMOD_SOUND *sound, FMOD_OPENSTATE *openstate, unsigned int *percentbuffered, FMOD_BOOL *starving, FMOD_BOOL *diskbusy);
-	return ErrNoImpl
+// If Starving is true, then you will most likely hear a stuttering/repeating sound as the decode buffer loops on itself and replays old data.
+// Now that this variable exists, you can detect buffer underrun and use something like "Channel.SetMute" to keep it quiet until it is not starving any more.
+//
+// Note: Always check State to determine the state of the sound. Do not assume that if this function returns nil then the sound has finished loading.
+// See "System.CreateSoundAsync" to wait for a sound to load instead of polling.
+func (s *Sound) OpenState() (SoundStatus, error) {
+	var openstate C.FMOD_OPENSTATE
+	var percentbuffered C.uint
+	var starving, diskbusy C.FMOD_BOOL
+	res := C.FMOD_Sound_GetOpenState(s.cptr, &openstate, &percentbuffered, &starving, &diskbusy)
+	status := SoundStatus{
+		State:           OpenState(openstate),
+		PercentBuffered: uint32(percentbuffered),
+		Starving:        setBool(starving),
+		DiskBusy:        setBool(diskbusy),
+	}
+	return status, errs[res]
 }
 
-// NOTE: Not implement yet
 // Reads data from an opened sound to a specified pointer, using the FMOD codec created internally.
 // This can be used for decoding data offline in small pieces (or big pieces), rather than playing and capturing it, or loading the whole file at once and having to lock / unlock the data.
 //
@@ -287,23 +446,31 @@ func (s *Sound) OpenState(openstate *C.FMOD_OPENSTATE, percentbuffered *C.uint,
 // NOTE! Thread safety. If you call this from another stream callback, or any other thread besides the main thread, make sure to put a criticalsection around the call,
 // and another around Sound::release in case the sound is still being read from while releasing.
 // This function is thread safe to call from a stream callback or different thread as long as it doesnt conflict with a call to "Sound.Release".
-func (s *Sound) ReadData(buffer *interface{}, lenbytes C.uint, read C.uint) error {
-	//FMOD_RESULT F_API FMOD_Sound_ReadData(FMOD_SOUND *sound, void *buffer, unsigned int lenbytes, unsigned int *read);
-	return ErrNoImpl
+//
+// Returns the number of bytes read into buffer. At the end of the sound, FMOD_ERR_FILE_EOF is returned with the bytes read before it.
+// See "Sound.Reader" for an io.Reader over the decoded data.
+func (s *Sound) ReadData(buffer []byte) (int, error) {
+	if len(buffer) == 0 {
+		return 0, nil
+	}
+	var read C.uint
+	res := C.FMOD_Sound_ReadData(s.cptr, unsafe.Pointer(&buffer[0]), C.uint(len(buffer)), &read)
+	return int(read), errs[res]
 }
 
-// NOTE: Not implement yet
 // Seeks a sound for use with data reading. This is not a function to 'seek a sound' for normal use.
 // This is for use in conjunction with "Sound.ReadData".
 //
+// pcm: Offset to seek to in PCM samples.
+//
 // Note. If a stream is opened and this function is called to read some data, then it will advance the internal file pointer, so data will be skipped if you play the stream.
 // Also calling position / time information functions will lead to misleading results.
 // A stream can be reset before playing by setting the position of the channel (ie using "Channel.SetPosition"), which will make it seek, reset and flush the stream buffer.
 // This will make it sound correct again.
 // Remember if you are calling readData and seekData on a stream it is up to you to cope with the side effects that may occur.
-func (s *Sound) SeekData(pcm C.uint) error {
-	//FMOD_RESULT F_API FMOD_Sound_SeekData(FMOD_SOUND *sound, unsigned int pcm);
-	return ErrNoImpl
+func (s *Sound) SeekData(pcm uint32) error {
+	res := C.FMOD_Sound_SeekData(s.cptr, C.uint(pcm))
+	return errs[res]
 }
 
 // Moves the sound from its existing SoundGroup to the specified sound group.
@@ -325,39 +492,68 @@ func (s *Sound) SoundGroup() (*SoundGroup, error) {
    Synchronization point API.  These points can come from markers embedded in wav files, and can also generate channel callbacks.
 */
 
-// NOTE: Not implement yet
 // Retrieves the number of sync points stored within a sound. These points can be user generated or can come from a wav file with embedded markers.
 // In sound forge, a marker can be added a wave file by clicking on the timeline / ruler, and right clicking then selecting 'Insert Marker/Region'.
 // Riff wrapped mp3 files are also supported.
-func (s *Sound) NumSyncPoints(numsyncpoints *C.int) error {
-	//FMOD_RESULT F_API FMOD_Sound_GetNumSyncPoints           (FMOD_SOUND *sound, int *numsyncpoints);
-	return ErrNoImpl
+func (s *Sound) NumSyncPoints() (int, error) {
+	var numsyncpoints C.int
+	res := C.FMOD_Sound_GetNumSyncPoints(s.cptr, &numsyncpoints)
+	return int(numsyncpoints), errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieve a handle to a sync point. These points can be user generated or can come from a wav file with embedded markers.
 //
 // index: Index of the sync point to retrieve. Use "Sound.NumSyncPoints" to determine the number of syncpoints.
 //
 // In sound forge, a marker can be added a wave file by clicking on the timeline / ruler, and right clicking then selecting 'Insert Marker/Region'.
 // Riff wrapped mp3 files are also supported.
-func (s *Sound) SyncPoint(index C.int, point **C.FMOD_SYNCPOINT) error {
-	//FMOD_RESULT F_API FMOD_Sound_GetSyncPoint               (FMOD_SOUND *sound, int index, FMOD_SYNCPOINT **point);
-	return ErrNoImpl
+func (s *Sound) SyncPoint(index int) (*SyncPoint, error) {
+	var point SyncPoint
+	res := C.FMOD_Sound_GetSyncPoint(s.cptr, C.int(index), &point.cptr)
+	return &point, errs[res]
 }
 
-// NOTE: Not implement yet
-// TODO: add more docs
 // Retrieves information on an embedded sync point. These points can be user generated or can come from a wav file with embedded markers.
+//
+// point: The sync point, as returned by "Sound.SyncPoint" or "Sound.AddSyncPoint".
+//
+// offsettype: The time format to return the offset in. Could be PCM samples or milliseconds for example.
+//
+// Returns the name and the offset of the sync point.
 // In sound forge, a marker can be added a wave file by clicking on the timeline / ruler, and right clicking then selecting 'Insert Marker/Region'.
 // Riff wrapped mp3 files are also supported.
-func (s *Sound) SyncPointInfo(point *C.FMOD_SYNCPOINT, name *C.char, namelen C.int, offset *C.uint, offsettype C.FMOD_TIMEUNIT) error {
-	//FMOD_RESULT F_API FMOD_Sound_GetSyncPointInfo           (FMOD_SOUND *sound, FMOD_SYNCPOINT *point, char *name, int namelen, unsigned int *offset, FMOD_TIMEUNIT offsettype);
-	return ErrNoImpl
+func (s *Sound) SyncPointInfo(point *SyncPoint, offsettype TimeUnit) (string, uint32, error) {
+	var name [256]C.char
+	var offset C.uint
+	res := C.FMOD_Sound_GetSyncPointInfo(s.cptr, point.cptr, &name[0], C.int(len(name)), &offset, C.FMOD_TIMEUNIT(offsettype))
+	return C.GoString(&name[0]), uint32(offset), errs[res]
+}
+
+// Retrieves all sync points of the sound, in index order.
+//
+// offsettype: The time format to return the offsets in. Could be PCM samples or milliseconds for example.
+func (s *Sound) SyncPoints(offsettype TimeUnit) ([]SyncPointInfo, error) {
+	count, err := s.NumSyncPoints()
+	if err != nil {
+		return nil, err
+	}
+	points := make([]SyncPointInfo, count)
+	for i := range points {
+		point, err := s.SyncPoint(i)
+		if err != nil {
+			return nil, err
+		}
+		name, offset, err := s.SyncPointInfo(point, offsettype)
+		if err != nil {
+			return nil, err
+		}
+		points[i] = SyncPointInfo{Point: point, Index: i, Name: name, Offset: offset}
+	}
+	return points, nil
 }
 
-// NOTE: Not implement yet
 // Adds a sync point at a specific time within the sound. These points can be user generated or can come from a wav file with embedded markers.
+//
 // offset: Offset in units specified by offsettype to add the callback syncpoint for a sound.
 //
 // offsettype: offset type to describe the offset provided. Could be PCM samples or milliseconds for example.
@@ -366,18 +562,41 @@ func (s *Sound) SyncPointInfo(point *C.FMOD_SYNCPOINT, name *C.char, namelen C.i
 //
 // In sound forge, a marker can be added a wave file by clicking on the timeline / ruler, and right clicking then selecting 'Insert Marker/Region'.
 // Riff wrapped mp3 files are also supported.
-func (s *Sound) AddSyncPoint(offset C.uint, offsettype C.FMOD_TIMEUNIT, name *C.char, point **C.FMOD_SYNCPOINT) error {
-	//FMOD_RESULT F_API FMOD_Sound_AddSyncPoint               (FMOD_SOUND *sound, unsigned int offset, FMOD_TIMEUNIT offsettype, const char *name, FMOD_SYNCPOINT **point);
-	return ErrNoImpl
+func (s *Sound) AddSyncPoint(offset uint32, offsettype TimeUnit, name string) (*SyncPoint, error) {
+	var point SyncPoint
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	res := C.FMOD_Sound_AddSyncPoint(s.cptr, C.uint(offset), C.FMOD_TIMEUNIT(offsettype), cname, &point.cptr)
+	return &point, errs[res]
 }
 
-// NOTE: Not implement yet
 // Deletes a syncpoint within the sound. These points can be user generated or can come from a wav file with embedded markers.
+// The point must not be used after it is deleted.
 // In sound forge, a marker can be added a wave file by clicking on the timeline / ruler, and right clicking then selecting 'Insert Marker/Region'.
 // Riff wrapped mp3 files are also supported.
-func (s *Sound) DeleteSyncPoint(point *C.FMOD_SYNCPOINT) error {
-	//FMOD_RESULT F_API FMOD_Sound_DeleteSyncPoint            (FMOD_SOUND *sound, FMOD_SYNCPOINT *point);
-	return ErrNoImpl
+func (s *Sound) DeleteSyncPoint(point *SyncPoint) error {
+	res := C.FMOD_Sound_DeleteSyncPoint(s.cptr, point.cptr)
+	point.cptr = nil
+	return errs[res]
+}
+
+// Adds a sync point for every marker of a WAV file, see "ReadWAVMarkers".
+// Use it for sounds which do not get the markers from FMOD, for example sounds opened with MODE_OPENRAW or MODE_OPENUSER, or compressed versions of a marked up WAV file.
+// Markers without a label are named after their cue point ID.
+func (s *Sound) AddWAVMarkers(markers []WAVMarker) ([]*SyncPoint, error) {
+	points := make([]*SyncPoint, 0, len(markers))
+	for _, marker := range markers {
+		name := marker.Label
+		if name == "" {
+			name = strconv.Itoa(int(marker.ID))
+		}
+		point, err := s.AddSyncPoint(marker.Position, TIMEUNIT_PCM, name)
+		if err != nil {
+			return points, err
+		}
+		points = append(points, point)
+	}
+	return points, nil
 }
 
 /*
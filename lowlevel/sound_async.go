@@ -0,0 +1,144 @@
+package lowlevel
+
+import (
+	"context"
+	"sync"
+)
+
+// The state of a sound, returned by "Sound.OpenState".
+type SoundStatus struct {
+	// State of the sound, or of the streaming buffer for streams.
+	State OpenState
+
+	// Filled percentage of a stream's file buffer, from 0 to 100.
+	PercentBuffered uint32
+
+	// True if a stream has decoded more than the stream file buffer has ready for it, and its data is stuttering.
+	Starving bool
+
+	// True if the disk is busy with this sound, or with other sounds of the same system.
+	DiskBusy bool
+}
+
+// A sound being loaded in the background, returned by "System.CreateSoundAsync".
+type SoundFuture struct {
+	mu        sync.Mutex
+	sound     *Sound
+	done      chan struct{}
+	completed bool
+	canceling bool
+	err       error
+}
+
+// resolve completes the future with err, unless it has already completed or is being canceled.
+func (f *SoundFuture) resolve(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.completed || f.canceling {
+		return
+	}
+	f.completed = true
+	f.err = err
+	close(f.done)
+}
+
+// cancel releases the sound and completes the future with err, unless it has already completed.
+// The sound is released before the future completes, so it is never used once freed.
+// The lock is not held while releasing, as FMOD may call the non blocking callback, and so resolve, until the load has stopped.
+func (f *SoundFuture) cancel(err error) {
+	f.mu.Lock()
+	if f.completed {
+		f.mu.Unlock()
+		return
+	}
+	f.canceling = true
+	sound := f.sound
+	f.mu.Unlock()
+
+	// Blocks until the background load has stopped.
+	sound.Release()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sound = nil
+	f.completed = true
+	f.err = err
+	close(f.done)
+}
+
+// Returns the sound being loaded, or nil once the load has been canceled.
+// Until the future is complete, the sound can only be used with functions that do not block, such as "Sound.OpenState".
+func (f *SoundFuture) Sound() *Sound {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sound
+}
+
+// Returns a channel which is closed once the sound has finished loading, has failed to load, or the load was canceled.
+func (f *SoundFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Returns nil once the sound has loaded, or the reason it did not.
+// Returns nil as well while the future is not complete.
+func (f *SoundFuture) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// Waits for the future to complete and returns the sound.
+// If ctx is done first, ctx.Err() is returned and the load carries on.
+func (f *SoundFuture) Wait(ctx context.Context) (*Sound, error) {
+	select {
+	case <-f.done:
+		return f.Sound(), f.Err()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Opens a sound in the background with MODE_NONBLOCKING, without stalling the caller.
+// The returned future completes when the sound reaches OPENSTATE_READY or OPENSTATE_ERROR.
+//
+// ctx: Cancels the load. If ctx is done before the sound has loaded, the sound is released and the future completes with ctx.Err().
+// "SoundFuture.Sound" then returns nil.
+//
+// name: Name of the file or URL to open encoded in a UTF-8 string.
+//
+// mode: Behaviour modifier for opening the sound. MODE_NONBLOCKING is added.
+//
+// exinfo: Pointer to a "CreatesSoundExInfo" which lets the user provide extended information while playing the sound. Optional. Specify nil to ignore.
+// Its NonBlockCallback, if any, is called before the future completes.
+//
+// If the sound fails to load, it must still be released with "Sound.Release". A canceled sound is already released.
+func (s *System) CreateSoundAsync(ctx context.Context, name string, mode Mode, exinfo *CreatesSoundExInfo) (*SoundFuture, error) {
+	info := CreatesSoundExInfo{}
+	if exinfo != nil {
+		info = *exinfo
+	}
+	f := &SoundFuture{done: make(chan struct{})}
+	callback := info.NonBlockCallback
+	info.NonBlockCallback = func(sound *Sound, err error) {
+		if callback != nil {
+			callback(sound, err)
+		}
+		f.resolve(err)
+	}
+
+	sound, err := s.CreateSound(name, mode|MODE_NONBLOCKING, &info)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	f.sound = sound
+	f.mu.Unlock()
+	go func() {
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			f.cancel(ctx.Err())
+		}
+	}()
+	return f, nil
+}
@@ -0,0 +1,161 @@
+package lowlevel
+
+/*
+#include <fmod.h>
+*/
+import "C"
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Describes the PCM data of a user created sound, see "System.CreateUserSound".
+type PCMSpec struct {
+	// Sample rate, in Hz.
+	Rate int
+
+	// Number of interleaved channels.
+	Channels int
+
+	// Length of the sound in PCM samples. Use 0 for an endless sound.
+	// Length * Channels * 4 must fit in a uint32, as FMOD sizes sounds in bytes.
+	Length uint32
+
+	// Size of the stream double buffer in PCM samples, which is the size of the buffers passed to the generator.
+	// Optional. Specify 0 to use the FMOD default.
+	DecodeBufferSize uint32
+
+	// Called when the sound is seeked, with the new position in PCM samples, for example when "Channel.SetPosition" is used.
+	// An endless sound is a looping stream as long as FMOD allows, over 3 hours for stereo at 48KHz.
+	// Its internal loop back to the start is not reported as a seek, but "Channel.Position" does wrap to 0.
+	// Optional. Specify nil to ignore seeks.
+	Seek func(position uint32)
+}
+
+// userSound holds the Go callbacks of a sound, either the generator of a sound created with "System.CreateUserSound",
+// or the NonBlockCallback of a "CreatesSoundExInfo".
+type userSound struct {
+	gen      func(buf []float32) int
+	seek     func(position uint32)
+	nonblock func(sound *Sound, err error)
+
+	// For endless sounds, the number of channels and the length of the loop, in PCM samples.
+	channels int
+	loop     uint32
+	// PCM samples generated since the start of the loop.
+	generated atomic.Uint64
+}
+
+// Registered sounds with Go callbacks.
+// FMOD may read from a sound before "System.CreateSound" returns it, so sounds are first registered by an id stored in the sound's userdata,
+// and then by sound pointer so the userdata remains available to the user.
+var userSounds = struct {
+	sync.Mutex
+	pending map[uintptr]*userSound
+	m       map[uintptr]*userSound
+	nextID  uintptr
+}{pending: make(map[uintptr]*userSound), m: make(map[uintptr]*userSound)}
+
+// addUserSound registers a sound which is being created, and returns the id to store in its userdata.
+func addUserSound(us *userSound) uintptr {
+	userSounds.Lock()
+	defer userSounds.Unlock()
+	userSounds.nextID++
+	userSounds.pending[userSounds.nextID] = us
+	return userSounds.nextID
+}
+
+// bindUserSound moves a pending registration to the created sound, or drops it if creation failed.
+func bindUserSound(id uintptr, sound *C.FMOD_SOUND) {
+	userSounds.Lock()
+	defer userSounds.Unlock()
+	if us, ok := userSounds.pending[id]; ok && sound != nil {
+		userSounds.m[uintptr(unsafe.Pointer(sound))] = us
+	}
+	delete(userSounds.pending, id)
+}
+
+// removeUserSound drops the registration of a released sound, if it has one.
+func removeUserSound(sound *C.FMOD_SOUND) {
+	userSounds.Lock()
+	delete(userSounds.m, uintptr(unsafe.Pointer(sound)))
+	userSounds.Unlock()
+}
+
+func lookupUserSound(sound *C.FMOD_SOUND) *userSound {
+	userSounds.Lock()
+	us, ok := userSounds.m[uintptr(unsafe.Pointer(sound))]
+	userSounds.Unlock()
+	if ok {
+		return us
+	}
+	// Still being created.
+	var id unsafe.Pointer
+	if C.FMOD_Sound_GetUserData(sound, &id) != C.FMOD_OK {
+		return nil
+	}
+	userSounds.Lock()
+	defer userSounds.Unlock()
+	return userSounds.pending[uintptr(id)]
+}
+
+// advance counts samples handed to FMOD, interleaved, to tell the loop of an endless sound from a seek.
+func (us *userSound) advance(samples int) {
+	if us.loop > 0 {
+		us.generated.Add(uint64(samples / us.channels))
+	}
+}
+
+// setPosition reports a seek to the user, unless it is FMOD looping an endless sound back to its start.
+func (us *userSound) setPosition(position uint32) {
+	if us.loop > 0 {
+		if position == 0 && us.generated.Load() >= uint64(us.loop) {
+			us.generated.Store(0)
+			return
+		}
+		us.generated.Store(uint64(position))
+	}
+	if us.seek != nil {
+		us.seek(position)
+	}
+}
+
+//export goSoundPCMRead
+func goSoundPCMRead(sound *C.FMOD_SOUND, data unsafe.Pointer, datalen C.uint) C.FMOD_RESULT {
+	buf := unsafe.Slice((*float32)(data), int(datalen)/4)
+	us := lookupUserSound(sound)
+	n := 0
+	if us != nil {
+		n = us.gen(buf)
+	}
+	if n < 0 {
+		n = 0
+	}
+	// Whatever the generator did not fill is played as silence.
+	if n < len(buf) {
+		clear(buf[n:])
+	}
+	if us != nil {
+		us.advance(len(buf))
+	}
+	return C.FMOD_OK
+}
+
+//export goSoundPCMSetPos
+func goSoundPCMSetPos(sound *C.FMOD_SOUND, subsound C.int, position C.uint, postype C.FMOD_TIMEUNIT) C.FMOD_RESULT {
+	us := lookupUserSound(sound)
+	if us != nil && TimeUnit(postype) == TIMEUNIT_PCM {
+		us.setPosition(uint32(position))
+	}
+	return C.FMOD_OK
+}
+
+//export goSoundNonBlock
+func goSoundNonBlock(sound *C.FMOD_SOUND, result C.FMOD_RESULT) C.FMOD_RESULT {
+	us := lookupUserSound(sound)
+	if us != nil && us.nonblock != nil {
+		us.nonblock(&Sound{cptr: sound}, errs[result])
+	}
+	return C.FMOD_OK
+}
@@ -0,0 +1,188 @@
+package lowlevel
+
+/*
+#include <stdlib.h>
+#include <fmod.h>
+extern FMOD_RESULT goSoundNonBlock(FMOD_SOUND *sound, FMOD_RESULT result);
+*/
+import "C"
+import "unsafe"
+
+// Use this structure with "System.CreateSound" when more control is needed over loading.
+// The possible reasons to use this with "System.CreateSound" are:
+//
+// - Loading a file from memory.
+// - Loading a file from within another larger (possibly wad/pak) file, by giving the loader an offset and length.
+// - To create a user created / non file based sound.
+// - To specify a starting subsound to seek to within a multi-sample sounds (ie FSB/DLS) when created as a stream.
+// - To specify which subsounds to load for multi-sample sounds (ie FSB/DLS) so that memory is saved and only a subset is actually loaded/read from disk.
+// - To specify 'piggyback' read and seek callbacks for capture of sound data as fmod reads and decodes it. Useful for ripping decoded PCM data from sounds as they are loaded / played.
+// - To specify a MIDI DLS sample set file to load when opening a MIDI file.
+//
+// Zero values are ignored, so only the members that are needed have to be set.
+// Members marked with [w] mean the variable can be written to. The user can set the value.
+type CreatesSoundExInfo struct {
+	// [w] Optional. Specify 0 to ignore.
+	// Number of bytes to load starting at FileOffset, or size of sound to create (if MODE_OPENUSER is used).
+	// Required if loading from memory. If 0 is specified, then it will use the size of the file (unless loading from memory then an error will be returned).
+	Length uint32
+
+	// [w] Optional. Specify 0 to ignore.
+	// Offset from start of the file to start loading from. This is useful for loading files from inside big data files.
+	FileOffset uint32
+
+	// [w] Optional. Specify 0 to ignore.
+	// Number of channels in a sound mandatory if MODE_OPENUSER or MODE_OPENRAW is used.
+	NumChannels int
+
+	// [w] Optional. Specify 0 to ignore.
+	// Default frequency of sound in Hz, mandatory if MODE_OPENUSER or MODE_OPENRAW is used.
+	// Other formats use the frequency determined by the file format.
+	DefaultFrequency int
+
+	// [w] Optional. Specify 0 or SOUND_FORMAT_NONE to ignore.
+	// Format of the sound, mandatory if MODE_OPENUSER or MODE_OPENRAW is used.
+	// Other formats use the format determined by the file format.
+	Format SoundFormat
+
+	// [w] Optional. Specify 0 to ignore.
+	// For streams. This determines the size of the double buffer (in PCM samples) that a stream uses.
+	// Use this for user created streams if you want to determine the size of the callback buffer passed to you.
+	DecodeBufferSize uint32
+
+	// [w] Optional. Specify 0 to ignore.
+	// In a multi-sample file format such as .FSB/.DLS, specify the initial subsound to seek to, only if MODE_CREATESTREAM is used.
+	InitialSubsound int
+
+	// [w] Optional. Specify 0 to ignore or have no subsounds.
+	// In a sound created with MODE_OPENUSER, specify the number of subsounds that are accessable with "Sound.SubSound".
+	// If not created with MODE_OPENUSER, this will limit the number of subsounds loaded within a multi-subsound file.
+	// If using FSB, then if InclusionList is used, this will shuffle subsounds down so that there are not any gaps.
+	// It will mean that the indices of the sounds will be different.
+	NumSubsounds int
+
+	// [w] Optional. Specify nil to ignore.
+	// In a multi-sample format such as .FSB/.DLS it may be desirable to specify only a subset of sounds to be loaded out of the whole file.
+	// This is a list of subsound indices to load into memory when created.
+	InclusionList []int
+
+	// [w] Optional. Specify "" to ignore.
+	// Filename for a DLS sample set when loading a MIDI file.
+	// If not specified, on Windows it will attempt to open /windows/system32/drivers/gm.dls or /windows/system32/drivers/etc/gm.dls, on Mac it will attempt to load /System/Library/Components/CoreAudio.component/Contents/Resources/gs_instruments.dls, otherwise the MIDI will fail to open.
+	// Current DLS support is for level 1 of the specification.
+	DLSName string
+
+	// [w] Optional. Specify "" to ignore.
+	// Key for encrypted FSB file. Without this key an encrypted FSB file will not load.
+	EncryptionKey string
+
+	// [w] Optional. Specify 0 to ignore.
+	// For sequenced formats with dynamic channel allocation such as .MID and .IT, this specifies the maximum voice count allowed while playing.
+	// .IT defaults to 64. .MID defaults to 32.
+	MaxPolyphony int
+
+	// [w] Optional. Specify 0 or SOUND_TYPE_UNKNOWN to ignore.
+	// Instead of scanning all codec types, use this to speed up loading by making it jump straight to this codec.
+	SuggestedSoundType SoundType
+
+	// [w] Optional. Specify nil to ignore.
+	// Specify a sound group if required, to put sound in as it is created.
+	InitialSoundGroup *SoundGroup
+
+	// [w] Optional. Specify 0 to ignore.
+	// For streams. Specify an initial position to seek the stream to.
+	InitialSeekPosition uint32
+
+	// [w] Optional. Specify 0 to ignore.
+	// For streams. Specify the time unit for the position set in InitialSeekPosition.
+	InitialSeekPosType TimeUnit
+
+	// [w] Optional. Specify false to ignore.
+	// Set to true to use fmod's built in file system. Ignores setFileSystem callbacks and also "System.SetFS" / "System.AttachFileSystem" callbacks.
+	// Useful for specific cases where you don't want to use your own file system but want to use fmod's file system (ie net streaming).
+	IgnoreSetFileSystem bool
+
+	// [w] Optional. Specify 0 to ignore.
+	// For MIDI files only. Allows you to set the granularity of MIDI event processing, in PCM samples. Default = 512.
+	MinMIDIGranularity uint32
+
+	// [w] Optional. Specify 0 to ignore.
+	// Specifies a thread index to execute non blocking load on.
+	// Allows for up to 5 threads to be used for loading at once. This is to avoid one load blocking another. Maximum value = 4.
+	NonBlockThreadID int
+
+	// [w] Optional. Specify nil to ignore.
+	// Called when a sound opened with MODE_NONBLOCKING has finished loading, with nil or the error that made it fail.
+	// It is also called when a non blocking "Sound.SubSound" or "Channel.SetPosition" on a stream completes.
+	// It is called from the FMOD loading thread, so it must return quickly and must not call FMOD functions on the sound.
+	// The userdata of the sound is used to find the callback, so "Sound.SetUserData" must not be used on the sound.
+	NonBlockCallback func(sound *Sound, err error)
+}
+
+// toC allocates the C structure, including the memory of the members it points to.
+// The result must be released with "freeExInfo". A nil exinfo gives a nil structure.
+func (e *CreatesSoundExInfo) toC() *C.FMOD_CREATESOUNDEXINFO {
+	if e == nil {
+		return nil
+	}
+	ce := (*C.FMOD_CREATESOUNDEXINFO)(C.calloc(1, C.sizeof_FMOD_CREATESOUNDEXINFO))
+	ce.cbsize = C.sizeof_FMOD_CREATESOUNDEXINFO
+	ce.length = C.uint(e.Length)
+	ce.fileoffset = C.uint(e.FileOffset)
+	ce.numchannels = C.int(e.NumChannels)
+	ce.defaultfrequency = C.int(e.DefaultFrequency)
+	ce.format = C.FMOD_SOUND_FORMAT(e.Format)
+	ce.decodebuffersize = C.uint(e.DecodeBufferSize)
+	ce.initialsubsound = C.int(e.InitialSubsound)
+	ce.numsubsounds = C.int(e.NumSubsounds)
+	if len(e.InclusionList) > 0 {
+		list := (*C.int)(C.calloc(C.size_t(len(e.InclusionList)), C.sizeof_int))
+		clist := unsafe.Slice(list, len(e.InclusionList))
+		for i, index := range e.InclusionList {
+			clist[i] = C.int(index)
+		}
+		ce.inclusionlist = list
+		ce.inclusionlistnum = C.int(len(e.InclusionList))
+	}
+	if e.DLSName != "" {
+		ce.dlsname = C.CString(e.DLSName)
+	}
+	if e.EncryptionKey != "" {
+		ce.encryptionkey = C.CString(e.EncryptionKey)
+	}
+	ce.maxpolyphony = C.int(e.MaxPolyphony)
+	ce.suggestedsoundtype = C.FMOD_SOUND_TYPE(e.SuggestedSoundType)
+	if e.InitialSoundGroup != nil {
+		ce.initialsoundgroup = e.InitialSoundGroup.cptr
+	}
+	ce.initialseekposition = C.uint(e.InitialSeekPosition)
+	ce.initialseekpostype = C.FMOD_TIMEUNIT(e.InitialSeekPosType)
+	ce.ignoresetfilesystem = C.int(getBool(e.IgnoreSetFileSystem))
+	ce.minmidigranularity = C.uint(e.MinMIDIGranularity)
+	ce.nonblockthreadid = C.int(e.NonBlockThreadID)
+	if e.NonBlockCallback != nil {
+		ce.nonblockcallback = (C.FMOD_SOUND_NONBLOCK_CALLBACK)(unsafe.Pointer(C.goSoundNonBlock))
+		id := addUserSound(&userSound{nonblock: e.NonBlockCallback})
+		*(*uintptr)(unsafe.Pointer(&ce.userdata)) = id
+	}
+	return ce
+}
+
+// bindExInfo attaches the Go callbacks registered by "CreatesSoundExInfo.toC" to the created sound, or drops them if sound is nil.
+func bindExInfo(ce *C.FMOD_CREATESOUNDEXINFO, sound *C.FMOD_SOUND) {
+	if ce == nil || ce.nonblockcallback == nil {
+		return
+	}
+	bindUserSound(*(*uintptr)(unsafe.Pointer(&ce.userdata)), sound)
+}
+
+// freeExInfo releases a structure allocated by "CreatesSoundExInfo.toC".
+func freeExInfo(ce *C.FMOD_CREATESOUNDEXINFO) {
+	if ce == nil {
+		return
+	}
+	C.free(unsafe.Pointer(ce.inclusionlist))
+	C.free(unsafe.Pointer(ce.dlsname))
+	C.free(unsafe.Pointer(ce.encryptionkey))
+	C.free(unsafe.Pointer(ce))
+}
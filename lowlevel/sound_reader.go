@@ -0,0 +1,181 @@
+package lowlevel
+
+/*
+#include <fmod.h>
+*/
+import "C"
+import "io"
+
+// soundReader reads the decoded data of a sound with "Sound.ReadData", see "Sound.Reader".
+type soundReader struct {
+	sound *Sound
+
+	// Size of one PCM sample for all channels, in bytes.
+	frame int64
+
+	// Current read position, in bytes.
+	pos int64
+}
+
+// Returns an io.ReadSeeker over the decoded PCM data of the sound, in the format returned by "Sound.Format".
+// The sound should be opened with MODE_OPENONLY, so FMOD has not read from it yet and the reader starts at the beginning.
+//
+// The reader uses "Sound.ReadData" and "Sound.SeekData", so the same restrictions apply.
+// Seeking with io.SeekEnd requires the length of the sound, use MODE_ACCURATETIME for formats such as MP3.
+func (s *Sound) Reader() (io.ReadSeeker, error) {
+	_, format, channels, bits, err := s.Format()
+	if err != nil {
+		return nil, err
+	}
+	if format == SOUND_FORMAT_NONE || bits == 0 {
+		return nil, errs[C.FMOD_ERR_FORMAT]
+	}
+	return &soundReader{sound: s, frame: int64(channels * bits / 8)}, nil
+}
+
+func (r *soundReader) Read(p []byte) (int, error) {
+	n, err := r.sound.ReadData(p)
+	r.pos += int64(n)
+	if err == errs[C.FMOD_ERR_FILE_EOF] || (err == nil && n == 0 && len(p) > 0) {
+		if n > 0 {
+			return n, nil
+		}
+		return 0, io.EOF
+	}
+	return n, err
+}
+
+// Seek moves to a byte offset. FMOD seeks by PCM sample, so the remainder of an offset inside a sample is read and discarded.
+func (r *soundReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		length, err := r.sound.Length(TIMEUNIT_PCMBYTES)
+		if err != nil {
+			return r.pos, err
+		}
+		offset += int64(length)
+	case io.SeekStart:
+	default:
+		return r.pos, errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	if offset < 0 {
+		return r.pos, errs[C.FMOD_ERR_INVALID_POSITION]
+	}
+	err := r.sound.SeekData(uint32(offset / r.frame))
+	if err != nil {
+		return r.pos, err
+	}
+	r.pos = offset / r.frame * r.frame
+	if skip := offset - r.pos; skip > 0 {
+		_, err = io.CopyN(io.Discard, r, skip)
+		if err == io.EOF {
+			err = nil
+		}
+	}
+	return r.pos, err
+}
+
+// Decoded PCM data with its format, returned by "System.DecodeFile" and "Sound.Decode".
+type PCMBuffer struct {
+	// Sample rate, in Hz.
+	Rate int
+
+	// Number of interleaved channels.
+	Channels int
+
+	// Sample format.
+	Format SoundFormat
+
+	// Bits per sample.
+	Bits int
+
+	// Interleaved samples, in native endianness.
+	Data []byte
+}
+
+// Retrieves the number of PCM samples per channel.
+func (b *PCMBuffer) Length() int {
+	if b.Channels == 0 || b.Bits == 0 {
+		return 0
+	}
+	return len(b.Data) / (b.Channels * b.Bits / 8)
+}
+
+// Retrieves the samples as 16 bit samples without copying them, or nil unless the format is SOUND_FORMAT_PCM16.
+func (b *PCMBuffer) Int16() []int16 {
+	if b.Format != SOUND_FORMAT_PCM16 {
+		return nil
+	}
+	return int16View(b.Data)
+}
+
+// Retrieves the samples as float samples, or nil unless the format is SOUND_FORMAT_PCM16 or SOUND_FORMAT_PCMFLOAT.
+// PCM16 samples are converted to the -1 to 1 range, PCMFLOAT samples are not copied.
+func (b *PCMBuffer) Float32() []float32 {
+	if b.Format != SOUND_FORMAT_PCM16 && b.Format != SOUND_FORMAT_PCMFLOAT {
+		return nil
+	}
+	return float32Samples(b.Data, b.Format)
+}
+
+// Decodes a whole file to PCM, using any format supported by FMOD.
+//
+// name: Name of the file to decode.
+//
+// The file is opened with MODE_OPENONLY and MODE_ACCURATETIME, decoded, then released.
+func (s *System) DecodeFile(name string) (*PCMBuffer, error) {
+	sound, err := s.CreateSound(name, MODE_OPENONLY|MODE_ACCURATETIME, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sound.Release()
+	return sound.Decode()
+}
+
+// Decodes the sound to PCM from its current read position to its end, see "Sound.Reader".
+func (s *Sound) Decode() (*PCMBuffer, error) {
+	_, format, channels, bits, err := s.Format()
+	if err != nil {
+		return nil, err
+	}
+	rate, _, err := s.Defaults()
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.Reader()
+	if err != nil {
+		return nil, err
+	}
+	// The length is only a hint, as it may be unknown or inaccurate.
+	length, _ := s.Length(TIMEUNIT_PCMBYTES)
+	data := make([]byte, 0, decodeCapacity(length))
+	buf := make([]byte, 16*1024)
+	for {
+		n, err := r.Read(buf)
+		data = append(data, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &PCMBuffer{Rate: int(rate), Channels: channels, Format: format, Bits: bits, Data: data}, nil
+}
+
+// Largest buffer preallocated by "Sound.Decode", larger sounds grow their buffer as they are decoded.
+const maxDecodeCapacity = 64 << 20
+
+// decodeCapacity returns the size to preallocate for decoding a sound of length bytes.
+// Sounds of unknown length, such as netstreams, report 0xFFFFFFFF and get no preallocation.
+func decodeCapacity(length uint32) int {
+	if length == 0xFFFFFFFF {
+		return 0
+	}
+	if length > maxDecodeCapacity {
+		return maxDecodeCapacity
+	}
+	return int(length)
+}
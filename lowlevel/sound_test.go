@@ -1,7 +1,16 @@
 package lowlevel
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
 	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -416,3 +425,653 @@ func TestSoundUserData(t *testing.T) {
 
 	<-done
 }
+
+func TestSoundLock(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	censor, err := system.CreateSound("media/censor.wav", MODE_CREATESAMPLE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := censor.Lock(0, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lock.Data1) != 64 || lock.Data2 != nil {
+		t.Errorf("expected a single 64 byte region, got %d and %d bytes", len(lock.Data1), len(lock.Data2))
+	}
+
+	samples, _ := lock.Int16()
+	if len(samples) != 32 {
+		t.Error("expected 32 PCM16 samples but got", len(samples))
+	}
+
+	if floats, _ := lock.Float32(); floats != nil {
+		t.Error("expected no float view of PCM16 data")
+	}
+
+	samples[0] = 1234
+
+	err = lock.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lock.Data1 != nil {
+		t.Error("expected the lock to be cleared by Unlock")
+	}
+
+	lock, err = censor.Lock(0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samples, _ = lock.Int16()
+	if samples[0] != 1234 {
+		t.Error("expected the written sample to be kept, got", samples[0])
+	}
+
+	err = censor.Unlock(lock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+func TestSoundDecode(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pcm, err := system.DecodeFile("media/censor.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pcm.Rate != 44100 || pcm.Channels != 1 || pcm.Format != SOUND_FORMAT_PCM16 || pcm.Bits != 16 {
+		t.Errorf("unexpected format %d Hz, %d channels, format %d, %d bits", pcm.Rate, pcm.Channels, pcm.Format, pcm.Bits)
+	}
+
+	censor, err := system.CreateSound("media/censor.wav", MODE_OPENONLY, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	length, err := censor.Length(TIMEUNIT_PCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pcm.Length() != int(length) {
+		t.Errorf("expected %d samples but got %d", length, pcm.Length())
+	}
+
+	r, err := censor.Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pos, err := r.Seek(-4, io.SeekEnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pos != int64(len(pcm.Data)-4) {
+		t.Error("unexpected position", pos)
+	}
+
+	tail, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(tail, pcm.Data[len(pcm.Data)-4:]) {
+		t.Error("expected the reader to return the decoded data")
+	}
+
+	pos, err = r.Seek(0, 3)
+	if err == nil {
+		t.Error("expected an error for an invalid whence")
+	}
+
+	if pos != int64(len(pcm.Data)) {
+		t.Error("expected the position to be left unchanged but got", pos)
+	}
+
+	if c := decodeCapacity(0xFFFFFFFF); c != 0 {
+		t.Error("expected no preallocation for an unknown length but got", c)
+	}
+
+	if c := decodeCapacity(1 << 31); c != maxDecodeCapacity {
+		t.Error("expected the preallocation to be capped but got", c)
+	}
+
+	guiro, err := system.DecodeFile("media/guiro.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if guiro.Length() == 0 || len(guiro.Float32()) == 0 {
+		t.Error("expected decoded mp3 data")
+	}
+
+	<-done
+}
+
+func TestSoundCreateExInfo(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Skip the canonical 44 byte WAV header and read the samples as headerless PCM
+	exinfo := &CreatesSoundExInfo{
+		FileOffset:       44,
+		NumChannels:      1,
+		DefaultFrequency: 22050,
+		Format:           SOUND_FORMAT_PCM16,
+	}
+	raw, err := system.CreateSound("media/censor.wav", MODE_OPENRAW|MODE_CREATESAMPLE, exinfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typ, format, channels, _, err := raw.Format()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if typ != SOUND_TYPE_RAW || format != SOUND_FORMAT_PCM16 || channels != 1 {
+		t.Errorf("unexpected format type %d, format %d, %d channels", typ, format, channels)
+	}
+
+	frequency, _, err := raw.Defaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if frequency != 22050 {
+		t.Error("frequency expected 22050 but got", frequency)
+	}
+
+	<-done
+}
+
+func TestSoundCreateFromMemory(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile("media/censor.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	censor, err := system.CreateSoundFromMemory(data, MODE_CREATESAMPLE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	length, err := censor.Length(TIMEUNIT_PCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if length == 0 {
+		t.Error("expected the sound to be decoded from memory")
+	}
+
+	// PCM data can be used in place
+	exinfo := &CreatesSoundExInfo{NumChannels: 1, DefaultFrequency: 44100, Format: SOUND_FORMAT_PCM16}
+	raw, err := system.CreateSoundFromMemory(data[44:], MODE_OPENMEMORY_POINT|MODE_OPENRAW|MODE_CREATESAMPLE, exinfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawLength, err := raw.Length(TIMEUNIT_PCMBYTES)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rawLength != uint32(len(data)-44) {
+		t.Errorf("expected %d bytes but got %d", len(data)-44, rawLength)
+	}
+
+	err = raw.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+func TestSoundCreateFromMemorySystemRelease(t *testing.T) {
+	system, err := SystemCreate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.SetOutput(OUTPUTTYPE_NOSOUND)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.Init(10, INIT_NORMAL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile("media/censor.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Left to be released along with the System.
+	exinfo := &CreatesSoundExInfo{NumChannels: 1, DefaultFrequency: 44100, Format: SOUND_FORMAT_PCM16}
+	raw, err := system.CreateSoundFromMemory(data[44:], MODE_OPENMEMORY_POINT|MODE_OPENRAW|MODE_CREATESAMPLE, exinfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The sound is freed by its System, not by its finalizer.
+	runtime.SetFinalizer(raw, nil)
+
+	key := uintptr(raw.Pointer())
+	soundMemory.Lock()
+	_, kept := soundMemory.m[key]
+	soundMemory.Unlock()
+	if !kept {
+		t.Fatal("expected the memory of the sound to be kept")
+	}
+
+	err = system.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	soundMemory.Lock()
+	_, kept = soundMemory.m[key]
+	soundMemory.Unlock()
+	if kept {
+		t.Error("expected the memory of the sound to be freed with its System")
+	}
+}
+
+func TestSoundCreateUserSound(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var phase float64
+	var calls int32
+	spec := PCMSpec{Rate: 44100, Channels: 1}
+	synth, err := system.CreateUserSound(spec, func(buf []float32) int {
+		atomic.AddInt32(&calls, 1)
+		for i := range buf {
+			buf[i] = float32(math.Sin(phase))
+			phase += 2 * math.Pi * 440 / 44100
+		}
+		return len(buf)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = system.PlaySound(synth, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 20; i++ {
+		err = system.Update()
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&calls) == 0 {
+		t.Error("expected the generator to be called")
+	}
+
+	err = synth.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+func TestSoundUserSoundLoop(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = system.CreateUserSound(PCMSpec{Rate: 44100, Channels: 2, Length: math.MaxUint32 / 4}, func(buf []float32) int { return 0 })
+	if err == nil {
+		t.Error("expected an error for a length overflowing the byte size")
+	}
+
+	var seeks []uint32
+	us := &userSound{seek: func(position uint32) { seeks = append(seeks, position) }, channels: 2, loop: 100}
+	us.advance(100)
+	us.setPosition(0)
+	us.setPosition(40)
+	us.advance(120)
+	us.setPosition(0)
+	us.advance(200)
+	us.setPosition(0)
+	if !reflect.DeepEqual(seeks, []uint32{0, 40}) {
+		t.Error("expected only the user seeks to be reported but got", seeks)
+	}
+
+	<-done
+}
+
+func TestSoundUserSoundSeek(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var seeks []uint32
+	spec := PCMSpec{Rate: 44100, Channels: 2, Seek: func(position uint32) {
+		mu.Lock()
+		seeks = append(seeks, position)
+		mu.Unlock()
+	}}
+	synth, err := system.CreateUserSound(spec, func(buf []float32) int { return len(buf) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel, err := system.PlaySound(synth, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = channel.SetPosition(4410, TIMEUNIT_PCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		err = system.Update()
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Starting the stream may also seek it to 0, only the seek of SetPosition is counted.
+	mu.Lock()
+	count := 0
+	for _, position := range seeks {
+		if position == 4410 {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Error("expected the seek to be reported once but got", seeks)
+	}
+	mu.Unlock()
+
+	err = synth.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+func TestSoundSyncPoints(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	censor, err := system.CreateSound("media/censor.wav", MODE_CREATESAMPLE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	point, err := censor.AddSyncPoint(100, TIMEUNIT_MS, "beep")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	points, err := censor.SyncPoints(TIMEUNIT_PCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(points) != 1 || points[0].Name != "beep" || points[0].Offset != 4410 {
+		t.Errorf("unexpected sync points %+v", points)
+	}
+
+	err = censor.DeleteSyncPoint(point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := censor.NumSyncPoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Error("expected no sync points but got", count)
+	}
+
+	<-done
+}
+
+func TestReadWAVMarkers(t *testing.T) {
+	le := binary.LittleEndian
+	var cue, adtl, wav bytes.Buffer
+
+	// Two cue points, listed out of order
+	binary.Write(&cue, le, uint32(2))
+	binary.Write(&cue, le, []uint32{2, 0, 0x61746164, 0, 0, 2000})
+	binary.Write(&cue, le, []uint32{1, 0, 0x61746164, 0, 0, 500})
+
+	adtl.WriteString("adtl")
+	adtl.WriteString("labl")
+	binary.Write(&adtl, le, uint32(10))
+	binary.Write(&adtl, le, uint32(1))
+	adtl.WriteString("intro\x00")
+
+	wav.WriteString("RIFF")
+	binary.Write(&wav, le, uint32(0))
+	wav.WriteString("WAVE")
+	wav.WriteString("cue ")
+	binary.Write(&wav, le, uint32(cue.Len()))
+	wav.Write(cue.Bytes())
+	wav.WriteString("LIST")
+	binary.Write(&wav, le, uint32(adtl.Len()))
+	wav.Write(adtl.Bytes())
+
+	markers, err := ReadWAVMarkers(&wav)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []WAVMarker{{ID: 1, Position: 500, Label: "intro"}, {ID: 2, Position: 2000}}
+	if !reflect.DeepEqual(markers, expected) {
+		t.Errorf("expected %+v but got %+v", expected, markers)
+	}
+
+	_, err = ReadWAVMarkers(bytes.NewReader([]byte("not a wave file")))
+	if err == nil {
+		t.Error("expected an error for a file which is not a WAV file")
+	}
+
+	// A cue chunk claiming 4 GiB, followed by a few bytes.
+	var hostile bytes.Buffer
+	hostile.WriteString("RIFF")
+	binary.Write(&hostile, le, uint32(0))
+	hostile.WriteString("WAVE")
+	hostile.WriteString("cue ")
+	binary.Write(&hostile, le, uint32(0xFFFFFFFF))
+	hostile.Write(cue.Bytes())
+	_, err = ReadWAVMarkers(&hostile)
+	if err == nil {
+		t.Error("expected an error for an oversized cue chunk")
+	}
+
+	// A cue chunk running past the end of the file.
+	var truncated bytes.Buffer
+	truncated.WriteString("RIFF")
+	binary.Write(&truncated, le, uint32(0))
+	truncated.WriteString("WAVE")
+	truncated.WriteString("cue ")
+	binary.Write(&truncated, le, uint32(cue.Len()+100))
+	truncated.Write(cue.Bytes())
+	_, err = ReadWAVMarkers(&truncated)
+	if err != io.ErrUnexpectedEOF {
+		t.Error("expected io.ErrUnexpectedEOF for a truncated cue chunk but got", err)
+	}
+}
+
+func TestSoundTags(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bell, err := system.CreateSound("media/bell.mp3", MODE_OPENONLY, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Tags read when opening the file are new until they are first retrieved.
+	updated, err := bell.UpdatedTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updated) == 0 {
+		t.Error("expected the tags of a new sound to be updated")
+	}
+
+	updated, err = bell.UpdatedTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updated) != 0 {
+		t.Errorf("expected no updated tags once retrieved but got %+v", updated)
+	}
+
+	_, changed, err := bell.UpdatedMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if changed {
+		t.Error("expected the metadata to be unchanged")
+	}
+
+	tags, err := bell.Tags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tags) == 0 {
+		t.Fatal("expected ID3 tags")
+	}
+
+	title, err := bell.TagByName("TIT2", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if title.Type != TAGTYPE_ID3V2 || title.String() != "agogo bell" {
+		t.Errorf("unexpected title tag %+v", title)
+	}
+
+	metadata, err := bell.Metadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if metadata.Title != "agogo bell" {
+		t.Error("expected title agogo bell but got", metadata.Title)
+	}
+
+	<-done
+}
+
+func TestSoundCreateAsync(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var called int32
+	exinfo := &CreatesSoundExInfo{
+		NonBlockCallback: func(sound *Sound, err error) {
+			atomic.AddInt32(&called, 1)
+		},
+	}
+	future, err := system.CreateSoundAsync(context.Background(), "media/censor.wav", MODE_CREATESAMPLE, exinfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	censor, err := future.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if atomic.LoadInt32(&called) == 0 {
+		t.Error("expected the NonBlockCallback to be called")
+	}
+
+	status, err := censor.OpenState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.State != OPENSTATE_READY {
+		t.Error("expected OPENSTATE_READY but got", status.State)
+	}
+
+	missing, err := system.CreateSoundAsync(context.Background(), "media/missing.wav", MODE_CREATESAMPLE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := missing.Wait(ctx); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	missing.Sound().Release()
+
+	canceled, cancelLoad := context.WithCancel(context.Background())
+	cancelLoad()
+	future, err = system.CreateSoundAsync(canceled, "media/censor.wav", MODE_CREATESAMPLE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-future.Done()
+	// The load may also win the race against the cancellation.
+	if future.Err() == context.Canceled && future.Sound() != nil {
+		t.Error("expected no sound after a cancellation")
+	}
+
+	<-done
+}
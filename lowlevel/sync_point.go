@@ -0,0 +1,157 @@
+package lowlevel
+
+/*
+#include <fmod.h>
+*/
+import "C"
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+)
+
+// A named position within a sound, see "Sound.SyncPoint".
+// Channels playing the sound raise "CHANNELCONTROL_CALLBACK_SYNCPOINT" when they pass it.
+type SyncPoint struct {
+	cptr *C.FMOD_SYNCPOINT
+}
+
+// Describes a sync point, returned by "Sound.SyncPoints".
+type SyncPointInfo struct {
+	// The sync point.
+	Point *SyncPoint
+
+	// Index of the sync point, as reported by "CHANNELCONTROL_CALLBACK_SYNCPOINT".
+	Index int
+
+	// Name of the sync point.
+	Name string
+
+	// Offset of the sync point, in the requested time unit.
+	Offset uint32
+}
+
+// A marker embedded in a WAV file, returned by "ReadWAVMarkers".
+type WAVMarker struct {
+	// Cue point ID.
+	ID uint32
+
+	// Position of the marker, in PCM samples.
+	Position uint32
+
+	// Text of the matching label, or "" if the marker has none.
+	Label string
+}
+
+// Largest "cue " or "LIST" chunk read by "ReadWAVMarkers". Larger "LIST" chunks are skipped, larger "cue " chunks are an error.
+const maxWAVMetadataChunk = 4 << 20
+
+// Reads the markers of a WAV file, from its "cue " chunk and the "labl" entries of its "LIST" "adtl" chunk.
+// The markers are sorted by position.
+func ReadWAVMarkers(r io.Reader) ([]WAVMarker, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, errs[C.FMOD_ERR_FORMAT]
+	}
+
+	var markers []WAVMarker
+	labels := make(map[uint32]string)
+chunks:
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err == io.EOF || err == io.ErrUnexpectedEOF {
+			break chunks
+		} else if err != nil {
+			return nil, err
+		}
+		id := string(chunk[0:4])
+		size := binary.LittleEndian.Uint32(chunk[4:8])
+		// Chunks are padded to an even size.
+		padded := int64(size) + int64(size&1)
+
+		switch {
+		case id == "cue " && size > maxWAVMetadataChunk:
+			return nil, errs[C.FMOD_ERR_FORMAT]
+		case id == "cue " || (id == "LIST" && size <= maxWAVMetadataChunk):
+			// Read through a limit so that a damaged size only allocates what the file actually holds.
+			data, err := io.ReadAll(io.LimitReader(r, int64(size)))
+			if err != nil {
+				return nil, err
+			}
+			if uint32(len(data)) < size {
+				return nil, io.ErrUnexpectedEOF
+			}
+			if size&1 == 1 {
+				// The pad byte may be missing at the end of the file.
+				io.CopyN(io.Discard, r, 1)
+			}
+			if id == "cue " {
+				markers = append(markers, readCuePoints(data)...)
+			} else {
+				readLabels(data, labels)
+			}
+		default:
+			if _, err := io.CopyN(io.Discard, r, padded); err == io.EOF {
+				break chunks
+			} else if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i := range markers {
+		markers[i].Label = labels[markers[i].ID]
+	}
+	sort.SliceStable(markers, func(i, j int) bool { return markers[i].Position < markers[j].Position })
+	return markers, nil
+}
+
+// readCuePoints decodes the cue points of a "cue " chunk.
+func readCuePoints(data []byte) []WAVMarker {
+	if len(data) < 4 {
+		return nil
+	}
+	count := int(binary.LittleEndian.Uint32(data))
+	data = data[4:]
+	var markers []WAVMarker
+	// Each cue point is made of an ID, a play order position, a chunk ID, a chunk start, a block start and a sample offset.
+	for i := 0; i < count && len(data) >= 24; i++ {
+		markers = append(markers, WAVMarker{
+			ID:       binary.LittleEndian.Uint32(data[0:4]),
+			Position: binary.LittleEndian.Uint32(data[20:24]),
+		})
+		data = data[24:]
+	}
+	return markers
+}
+
+// readLabels decodes the "labl" entries of a "LIST" "adtl" chunk into labels, keyed by cue point ID.
+func readLabels(data []byte, labels map[uint32]string) {
+	if len(data) < 4 || string(data[0:4]) != "adtl" {
+		return
+	}
+	data = data[4:]
+	for len(data) >= 8 {
+		id := string(data[0:4])
+		size := int(binary.LittleEndian.Uint32(data[4:8]))
+		data = data[8:]
+		if size > len(data) {
+			return
+		}
+		if id == "labl" && size >= 4 {
+			text := data[4:size]
+			if end := bytes.IndexByte(text, 0); end >= 0 {
+				text = text[:end]
+			}
+			labels[binary.LittleEndian.Uint32(data[0:4])] = string(text)
+		}
+		if size&1 == 1 && size < len(data) {
+			size++
+		}
+		data = data[size:]
+	}
+}
@@ -3,9 +3,28 @@ package lowlevel
 /*
 #include <stdlib.h>
 #include <fmod.h>
+extern FMOD_RESULT goSystemCallback(FMOD_SYSTEM *system, FMOD_SYSTEM_CALLBACK_TYPE type, void *commanddata1, void *commanddata2, void *userdata);
+extern FMOD_RESULT goFileOpen(char *name, unsigned int *filesize, void **handle, void *userdata);
+extern FMOD_RESULT goFileClose(void *handle, void *userdata);
+extern FMOD_RESULT goFileRead(void *handle, void *buffer, unsigned int sizebytes, unsigned int *bytesread, void *userdata);
+extern FMOD_RESULT goFileSeek(void *handle, unsigned int pos, void *userdata);
+extern FMOD_RESULT goFileAsyncRead(FMOD_ASYNCREADINFO *info, void *userdata);
+extern FMOD_RESULT goFileAsyncCancel(FMOD_ASYNCREADINFO *info, void *userdata);
+extern FMOD_RESULT goFileAttachOpen(char *name, unsigned int *filesize, void **handle, void *userdata);
+extern FMOD_RESULT goFileAttachClose(void *handle, void *userdata);
+extern FMOD_RESULT goFileAttachRead(void *handle, void *buffer, unsigned int sizebytes, unsigned int *bytesread, void *userdata);
+extern FMOD_RESULT goFileAttachSeek(void *handle, unsigned int pos, void *userdata);
+extern FMOD_RESULT goSoundPCMRead(FMOD_SOUND *sound, void *data, unsigned int datalen);
+extern FMOD_RESULT goSoundPCMSetPos(FMOD_SOUND *sound, int subsound, unsigned int position, FMOD_TIMEUNIT postype);
+
+static void asyncReadDone(FMOD_ASYNCREADINFO *info, FMOD_RESULT result) {
+	info->done(info, result);
+}
 */
 import "C"
 import (
+	"io/fs"
+	"math"
 	"runtime"
 	"unsafe"
 )
@@ -16,6 +35,17 @@ type System struct {
 	cptr *C.FMOD_SYSTEM
 }
 
+// Wraps a raw FMOD_SYSTEM pointer which was obtained from another FMOD API, for example "Studio::System::getLowLevelSystem".
+// The returned System does not own the object, so it has no finalizer attached.
+func SystemFromPointer(ptr unsafe.Pointer) *System {
+	return &System{cptr: (*C.FMOD_SYSTEM)(ptr)}
+}
+
+// Returns the raw FMOD_SYSTEM pointer, for passing the object to another FMOD API.
+func (s *System) Pointer() unsafe.Pointer {
+	return unsafe.Pointer(s.cptr)
+}
+
 /*
    'System' API
 */
@@ -33,7 +63,11 @@ func SystemCreate() (*System, error) {
 // Closes and frees a system object and its resources.
 // This function also calls "System.Close()", so calling close before this function is not necessary.
 func (s *System) Release() error {
+	setSystemCallback(s.cptr, 0, nil)
 	res := C.FMOD_System_Release(s.cptr)
+	if res == C.FMOD_OK {
+		freeSystemSoundMemory(s.cptr)
+	}
 	return errs[res]
 }
 
@@ -181,24 +215,109 @@ func (s *System) DSPBufferSize() (uint32, int, error) {
 	return uint32(bufferlength), int(numbuffers), errs[res]
 }
 
-// TODO: add more docs
-// NOTE: Not implement yet
-// Specify user callbacks for FMOD's internal file manipulation functions. This function is useful for replacing FMOD's file system with a game system's own file reading API.
-func (s *System) SetFileSystem(useropen C.FMOD_FILE_OPEN_CALLBACK, userclose C.FMOD_FILE_CLOSE_CALLBACK, userread C.FMOD_FILE_READ_CALLBACK, userseek C.FMOD_FILE_SEEK_CALLBACK, userasyncread C.FMOD_FILE_ASYNCREAD_CALLBACK, userasynccancel C.FMOD_FILE_ASYNCCANCEL_CALLBACK, blockalign C.int) error {
-	//FMOD_RESULT F_API FMOD_System_SetFileSystem             (FMOD_SYSTEM *system, FMOD_FILE_OPEN_CALLBACK useropen, FMOD_FILE_CLOSE_CALLBACK userclose, FMOD_FILE_READ_CALLBACK userread, FMOD_FILE_SEEK_CALLBACK userseek, FMOD_FILE_ASYNCREAD_CALLBACK userasyncread, FMOD_FILE_ASYNCCANCEL_CALLBACK userasynccancel, int blockalign);
-	return ErrNoImpl
+// Replaces FMOD's file system with fsys, so every file opened by "System.CreateSound", "System.CreateStream"
+// and Studio bank loading is resolved through it. This makes embed.FS, archives or encrypted packs usable as sound sources.
+//
+// fsys: The file system to read from. Names passed to FMOD are cleaned and made relative before being opened.
+// Pass nil to restore FMOD's own file system.
+//
+// FMOD does not tell its file callbacks which System they are called for, so the file system is shared by
+// every System of the process that calls "System.SetFS" or "System.SetFSAsync".
+// Files that implement io.ReaderAt or io.Seeker are read directly, others are reopened when FMOD seeks backwards.
+// FMOD keeps buffering file data, so reads are made in blocks of its default 2048 bytes alignment rather than one per codec read.
+func (s *System) SetFS(fsys fs.FS) error {
+	setFS(fsys, 0)
+	if fsys == nil {
+		res := C.FMOD_System_SetFileSystem(s.cptr, nil, nil, nil, nil, nil, nil, -1)
+		return errs[res]
+	}
+	res := C.FMOD_System_SetFileSystem(s.cptr,
+		(C.FMOD_FILE_OPEN_CALLBACK)(unsafe.Pointer(C.goFileOpen)),
+		(C.FMOD_FILE_CLOSE_CALLBACK)(unsafe.Pointer(C.goFileClose)),
+		(C.FMOD_FILE_READ_CALLBACK)(unsafe.Pointer(C.goFileRead)),
+		(C.FMOD_FILE_SEEK_CALLBACK)(unsafe.Pointer(C.goFileSeek)),
+		nil, nil, -1)
+	return errs[res]
 }
 
-// TODO: add more docs
-// NOTE: Not implement yet
-// Function to allow a user to 'piggyback' on FMOD's file reading routines.
-// This allows users to capture data as FMOD reads it, which may be useful for ripping the raw data that FMOD reads for hard to support sources (for example internet streams).
+// Same as "System.SetFS", but reads are serviced asynchronously by a pool of goroutines instead of blocking FMOD's threads.
 //
-// NOTE! Do not use this to 'override' FMOD's file system! That is what setFileSystem is for.
-// This function is purely for 'snooping' and letting FMOD do its own file access, but if you want to capture what FMOD is reading you can do it with this function.
-func (s *System) AttachFileSystem(useropen C.FMOD_FILE_OPEN_CALLBACK, userclose C.FMOD_FILE_CLOSE_CALLBACK, userread C.FMOD_FILE_READ_CALLBACK, userseek C.FMOD_FILE_SEEK_CALLBACK) error {
-	//FMOD_RESULT F_API FMOD_System_AttachFileSystem          (FMOD_SYSTEM *system, FMOD_FILE_OPEN_CALLBACK useropen, FMOD_FILE_CLOSE_CALLBACK userclose, FMOD_FILE_READ_CALLBACK userread, FMOD_FILE_SEEK_CALLBACK userseek);
-	return ErrNoImpl
+// workers: Number of goroutines reading from fsys, at least 1.
+//
+// FMOD issues asynchronous read requests with a priority and a completion function, which the workers call once the data is ready.
+// Requests canceled by FMOD before a worker picks them up are completed with a "disk ejected" error, as FMOD expects.
+func (s *System) SetFSAsync(fsys fs.FS, workers int) error {
+	if fsys == nil {
+		return s.SetFS(nil)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	setFS(fsys, workers)
+	res := C.FMOD_System_SetFileSystem(s.cptr,
+		(C.FMOD_FILE_OPEN_CALLBACK)(unsafe.Pointer(C.goFileOpen)),
+		(C.FMOD_FILE_CLOSE_CALLBACK)(unsafe.Pointer(C.goFileClose)),
+		nil, nil,
+		(C.FMOD_FILE_ASYNCREAD_CALLBACK)(unsafe.Pointer(C.goFileAsyncRead)),
+		(C.FMOD_FILE_ASYNCCANCEL_CALLBACK)(unsafe.Pointer(C.goFileAsyncCancel)),
+		-1)
+	return errs[res]
+}
+
+// setFS installs fsys as the process wide file system, and starts workers goroutines for asynchronous reads if workers > 0.
+func setFS(fsys fs.FS, workers int) {
+	fileSystem.Lock()
+	fileSystem.fsys = fsys
+	fileSystem.Unlock()
+
+	asyncReads.Lock()
+	old := asyncReads.pool
+	asyncReads.pool = nil
+	if workers > 0 {
+		pool := &asyncPool{queue: make(chan *asyncRead, 64*workers), closing: make(chan struct{})}
+		for i := 0; i < workers; i++ {
+			go asyncWorker(pool.queue)
+		}
+		asyncReads.pool = pool
+	}
+	if old != nil {
+		close(old.closing)
+	}
+	asyncReads.Unlock()
+
+	if old != nil {
+		// Running workers exit once the queued reads are done.
+		// The queue is only closed once no sender can use it any more.
+		old.senders.Wait()
+		close(old.queue)
+	}
+}
+
+func asyncReadDone(info *C.FMOD_ASYNCREADINFO, result C.FMOD_RESULT) {
+	C.asyncReadDone(info, result)
+}
+
+// Function to allow a user to 'piggyback' on FMOD's file reading routines.
+// FMOD keeps doing its own file access, and logger is told about every open, close, read and seek, for example to log file access.
+//
+// logger: Called with a "FileAccess" for each file operation. Pass nil to detach it.
+//
+// NOTE! Do not use this to 'override' FMOD's file system! That is what "System.SetFS" is for.
+// Like "System.SetFS", the logger is shared by every System of the process.
+func (s *System) AttachFileSystem(logger func(access FileAccess)) error {
+	fileSystem.Lock()
+	fileSystem.logger = logger
+	fileSystem.Unlock()
+	if logger == nil {
+		res := C.FMOD_System_AttachFileSystem(s.cptr, nil, nil, nil, nil)
+		return errs[res]
+	}
+	res := C.FMOD_System_AttachFileSystem(s.cptr,
+		(C.FMOD_FILE_OPEN_CALLBACK)(unsafe.Pointer(C.goFileAttachOpen)),
+		(C.FMOD_FILE_CLOSE_CALLBACK)(unsafe.Pointer(C.goFileAttachClose)),
+		(C.FMOD_FILE_READ_CALLBACK)(unsafe.Pointer(C.goFileAttachRead)),
+		(C.FMOD_FILE_SEEK_CALLBACK)(unsafe.Pointer(C.goFileAttachSeek)))
+	return errs[res]
 }
 
 // Sets advanced features like configuring memory and cpu usage for FMOD_CREATECOMPRESSEDSAMPLE usage.
@@ -223,37 +342,61 @@ func (s *System) AdvancedSettings() (*AdvancedSettings, error) {
 	return as, errs[res]
 }
 
-// TODO: add more docs
-// NOTE: Not implement yet
 // Sets a system callback to catch various fatal or informational events.
-func (s *System) SetCallback(callback C.FMOD_SYSTEM_CALLBACK, callbackmask C.FMOD_SYSTEM_CALLBACK_TYPE) error {
-	//FMOD_RESULT F_API FMOD_System_SetCallback               (FMOD_SYSTEM *system, FMOD_SYSTEM_CALLBACK callback, FMOD_SYSTEM_CALLBACK_TYPE callbackmask);
-	return ErrNoImpl
+//
+// callbackmask: A bitfield of "SystemCallbackType" values, specifying which callbacks are required.
+//
+// callback: Called with a "SystemEvent" for each callback type in callbackmask. Pass nil to remove the callback.
+//
+// Callbacks are raised from different threads: "SYSTEM_CALLBACK_PREMIX", "SYSTEM_CALLBACK_MIDMIX" and "SYSTEM_CALLBACK_POSTMIX"
+// come from the mixer thread, "SYSTEM_CALLBACK_ERROR" from whichever thread called the failing function, and the others mostly from "System.Update".
+// The callback must therefore be safe for concurrent use and return quickly.
+// Using "SYSTEM_CALLBACK_ERROR" is a convenient way to route every FMOD error into a logger.
+func (s *System) SetCallback(callbackmask SystemCallbackType, callback func(ev SystemEvent)) error {
+	if callback == nil {
+		res := C.FMOD_System_SetCallback(s.cptr, nil, 0)
+		setSystemCallback(s.cptr, 0, nil)
+		return errs[res]
+	}
+	setSystemCallback(s.cptr, callbackmask, callback)
+	res := C.FMOD_System_SetCallback(s.cptr, (C.FMOD_SYSTEM_CALLBACK)(unsafe.Pointer(C.goSystemCallback)), C.FMOD_SYSTEM_CALLBACK_TYPE(callbackmask))
+	if res != C.FMOD_OK {
+		setSystemCallback(s.cptr, 0, nil)
+	}
+	return errs[res]
 }
 
 /*
    Plug-in support.
 */
 
-// NOTE: Not implement yet
 // Specify a base search path for plugins so they can be placed somewhere else than the directory of the main executable.
-func (s *System) SetPluginPath(path *C.char) error {
-	//FMOD_RESULT F_API FMOD_System_SetPluginPath             (FMOD_SYSTEM *system, const char *path);
-	return ErrNoImpl
+func (s *System) SetPluginPath(path string) error {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	res := C.FMOD_System_SetPluginPath(s.cptr, cpath)
+	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Loads an FMOD plugin. This could be a DSP, file format or output plugin.
-func (s *System) LoadPlugin(filename *C.char, handle *C.uint, priority C.uint) error {
-	//FMOD_RESULT F_API FMOD_System_LoadPlugin                (FMOD_SYSTEM *system, const char *filename, unsigned int *handle, unsigned int priority);
-	return ErrNoImpl
+//
+// filename: Filename of the plugin to be loaded, relative to the path given to "System.SetPluginPath".
+//
+// priority: Codec plugins only, priority of the codec compared to other codecs, where 0 is the highest priority.
+//
+// Use the returned handle with "System.CreateDSPByPlugin", "System.SetOutputByPlugin" or "System.PluginInfo".
+func (s *System) LoadPlugin(filename string, priority uint32) (PluginHandle, error) {
+	var handle C.uint
+	cfilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cfilename))
+	res := C.FMOD_System_LoadPlugin(s.cptr, cfilename, &handle, C.uint(priority))
+	return PluginHandle(handle), errs[res]
 }
 
-// NOTE: Not implement yet
 // Unloads a plugin from memory.
-func (s *System) UnloadPlugin(handle C.uint) error {
-	//FMOD_RESULT F_API FMOD_System_UnloadPlugin              (FMOD_SYSTEM *system, unsigned int handle);
-	return ErrNoImpl
+func (s *System) UnloadPlugin(handle PluginHandle) error {
+	res := C.FMOD_System_UnloadPlugin(s.cptr, C.uint(handle))
+	return errs[res]
 }
 
 // Retrieves the number of available plugins loaded into FMOD at the current time.
@@ -264,53 +407,89 @@ func (s *System) NumPlugins(plugintype PluginType) (int, error) {
 	return int(numplugins), errs[res]
 }
 
-// NOTE: Not implement yet
-//Retrieves the handle of a plugin based on its type and relative index. Use "System.NumPlugins" to enumerate plugins.
-func (s *System) PluginHandle(plugintype C.FMOD_PLUGINTYPE, index C.int, handle *C.uint) error {
-	//FMOD_RESULT F_API FMOD_System_GetPluginHandle           (FMOD_SYSTEM *system, FMOD_PLUGINTYPE plugintype, int index, unsigned int *handle);
-	return ErrNoImpl
+// Retrieves the handle of a plugin based on its type and relative index. Use "System.NumPlugins" to enumerate plugins.
+func (s *System) PluginHandle(plugintype PluginType, index int) (PluginHandle, error) {
+	var handle C.uint
+	res := C.FMOD_System_GetPluginHandle(s.cptr, C.FMOD_PLUGINTYPE(plugintype), C.int(index), &handle)
+	return PluginHandle(handle), errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieves information to display for the selected plugin.
-func (s *System) PluginInfo(handle C.uint, plugintype *C.FMOD_PLUGINTYPE, name *C.char, version *C.int) error {
-	//FMOD_RESULT F_API FMOD_System_GetPluginInfo             (FMOD_SYSTEM *system, unsigned int handle, FMOD_PLUGINTYPE *plugintype, char *name, int namelen, unsigned int *version);
-	return ErrNoImpl
+func (s *System) PluginInfo(handle PluginHandle) (PluginInfo, error) {
+	var plugintype C.FMOD_PLUGINTYPE
+	var name [256]C.char
+	var version C.uint
+	res := C.FMOD_System_GetPluginInfo(s.cptr, C.uint(handle), &plugintype, &name[0], C.int(len(name)), &version)
+	info := PluginInfo{
+		Handle:  handle,
+		Type:    PluginType(plugintype),
+		Name:    C.GoString(&name[0]),
+		Version: uint32(version),
+	}
+	return info, errs[res]
+}
+
+// Retrieves the information of every plugin of a type, built in or loaded with "System.LoadPlugin".
+// plugintype: Plugin type such as PLUGINTYPE_OUTPUT, PLUGINTYPE_CODEC or PLUGINTYPE_DSP.
+func (s *System) Plugins(plugintype PluginType) ([]PluginInfo, error) {
+	numplugins, err := s.NumPlugins(plugintype)
+	if err != nil {
+		return nil, err
+	}
+	plugins := make([]PluginInfo, 0, numplugins)
+	for i := 0; i < numplugins; i++ {
+		handle, err := s.PluginHandle(plugintype, i)
+		if err != nil {
+			return nil, err
+		}
+		info, err := s.PluginInfo(handle)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, info)
+	}
+	return plugins, nil
 }
 
-// NOTE: Not implement yet
 // Selects an output type based on the enumerated list of outputs including FMOD and 3rd party output plugins.
-func (s *System) SetOutputByPlugin(handle C.uint) error {
-	//FMOD_RESULT F_API FMOD_System_SetOutputByPlugin         (FMOD_SYSTEM *system, unsigned int handle);
-	return ErrNoImpl
+func (s *System) SetOutputByPlugin(handle PluginHandle) error {
+	res := C.FMOD_System_SetOutputByPlugin(s.cptr, C.uint(handle))
+	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Returns the currently selected output as an id in the list of output plugins.
 // This function can be called after FMOD is already activated. You can use it to change the output mode at runtime.
 // If SYSTEM_CALLBACK_DEVICELISTCHANGED is specified use the setOutput call to change to "OUTPUTTYPE_NOSOUND" if no more sound card drivers exist.
-func (s *System) OutputByPlugin(handle *C.uint) error {
-	//FMOD_RESULT F_API FMOD_System_GetOutputByPlugin         (FMOD_SYSTEM *system, unsigned int *handle);
-	return ErrNoImpl
+func (s *System) OutputByPlugin() (PluginHandle, error) {
+	var handle C.uint
+	res := C.FMOD_System_GetOutputByPlugin(s.cptr, &handle)
+	return PluginHandle(handle), errs[res]
 }
 
-// NOTE: Not implement yet
 // Creates a DSP unit object which is either built in or loaded as a plugin, to be inserted into a DSP network, for the purposes of sound filtering or sound generation.
 // This function creates a DSP unit that can be enumerated by using "System.NumPlugins" and "System.PluginInfo".
 //
 // A DSP unit can generate or filter incoming data.
 // To be active, a unit must be inserted into the FMOD DSP network to be heard.
 // Use functions such as "ChannelGroup.AddDSP", "Channel.AddDSP" or "DSP.AddInput" to do this.
-func (s *System) CreateDSPByPlugin(handle C.uint, dsp **C.FMOD_DSP) error {
-	//FMOD_RESULT F_API FMOD_System_CreateDSPByPlugin         (FMOD_SYSTEM *system, unsigned int handle, FMOD_DSP **dsp);
-	return ErrNoImpl
+func (s *System) CreateDSPByPlugin(handle PluginHandle) (*DSP, error) {
+	var dsp DSP
+	defer runtime.SetFinalizer(&dsp, (*DSP).Release)
+	res := C.FMOD_System_CreateDSPByPlugin(s.cptr, C.uint(handle), &dsp.cptr)
+	return &dsp, errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieve the description structure for a pre-existing DSP plugin.
-func (s *System) DSPInfoByPlugin(handle C.uint, description **C.FMOD_DSP_DESCRIPTION) error {
-	//FMOD_RESULT F_API FMOD_System_GetDSPInfoByPlugin        (FMOD_SYSTEM *system, unsigned int handle, const FMOD_DSP_DESCRIPTION **description);
-	return ErrNoImpl
+// The New function of the returned description is nil, so it describes the plugin but cannot be passed to "System.CreateDSP".
+// Use "System.CreateDSPByPlugin" to create units of the plugin.
+func (s *System) DSPInfoByPlugin(handle PluginHandle) (DSPDesc, error) {
+	var cdesc *C.FMOD_DSP_DESCRIPTION
+	res := C.FMOD_System_GetDSPInfoByPlugin(s.cptr, C.uint(handle), &cdesc)
+	var description DSPDesc
+	if res == C.FMOD_OK {
+		description.fromC(cdesc)
+	}
+	return description, errs[res]
 }
 
 // NOTE: Not implement yet
@@ -578,20 +757,34 @@ func (s *System) MixerResume() error {
 	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Gets the default matrix used to convert from one speaker mode to another.
-// The gain for source channel 's' to target channel 't' is matrix[t * matrixhop + s].
+// The matrix has one row per channel of the target speaker mode and one column per channel of the source speaker mode,
+// so the gain for source channel 's' to target channel 't' is matrix.At(t, s).
 // If 'sourcespeakermode' or 'targetspeakermode' is SPEAKERMODE_RAW, this function will return error.
-func (s *System) DefaultMixMatrix(sourcespeakermode, targetspeakermode C.FMOD_SPEAKERMODE, matrix *C.float, matrixhop C.int) error {
-	//FMOD_RESULT F_API FMOD_System_GetDefaultMixMatrix       (FMOD_SYSTEM *system, FMOD_SPEAKERMODE sourcespeakermode, FMOD_SPEAKERMODE targetspeakermode, float *matrix, int matrixhop);
-	return ErrNoImpl
+//
+// See "System.DownmixMatrix" and "System.UpmixMatrix".
+func (s *System) DefaultMixMatrix(sourcespeakermode, targetspeakermode SpeakerMode) (*MixMatrix, error) {
+	in, err := s.SpeakerModeChannels(sourcespeakermode)
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.SpeakerModeChannels(targetspeakermode)
+	if err != nil {
+		return nil, err
+	}
+	matrix, err := NewMixMatrix(out, in)
+	if err != nil {
+		return nil, err
+	}
+	res := C.FMOD_System_GetDefaultMixMatrix(s.cptr, C.FMOD_SPEAKERMODE(sourcespeakermode), C.FMOD_SPEAKERMODE(targetspeakermode), matrix.levels(), C.int(in))
+	return matrix, errs[res]
 }
 
-// NOTE: Not implement yet
 // Gets the a speaker mode's channel count.
-func (s *System) SpeakerModeChannels(mode C.FMOD_SPEAKERMODE, channels *C.int) error {
-	//FMOD_RESULT F_API FMOD_System_GetSpeakerModeChannels    (FMOD_SYSTEM *system, FMOD_SPEAKERMODE mode, int *channels);
-	return ErrNoImpl
+func (s *System) SpeakerModeChannels(mode SpeakerMode) (int, error) {
+	var channels C.int
+	res := C.FMOD_System_GetSpeakerModeChannels(s.cptr, C.FMOD_SPEAKERMODE(mode), &channels)
+	return int(channels), errs[res]
 }
 
 /*
@@ -669,14 +862,16 @@ func (s *System) SoundRAM() (map[string]int, error) {
 //
 // mode: Behaviour modifier for opening the sound. See FMOD_MODE.
 //
-// exinfo: Pointer to a FMOD_CREATESOUNDEXINFO which lets the user provide extended information while playing the sound. Optional. Specify 0 or NULL to ignore.
+// exinfo: Pointer to a "CreatesSoundExInfo" which lets the user provide extended information while playing the sound. Optional. Specify nil to ignore.
 func (s *System) CreateSound(name_or_data string, mode Mode, exinfo *CreatesSoundExInfo) (*Sound, error) {
 	var sound Sound
 	defer runtime.SetFinalizer(&sound, (*Sound).Release)
 	cname_or_data := C.CString(name_or_data)
 	defer C.free(unsafe.Pointer(cname_or_data))
-	// FIX me
-	res := C.FMOD_System_CreateSound(s.cptr, cname_or_data, C.FMOD_MODE(mode), (*C.FMOD_CREATESOUNDEXINFO)(null), &sound.cptr)
+	cexinfo := exinfo.toC()
+	defer freeExInfo(cexinfo)
+	res := C.FMOD_System_CreateSound(s.cptr, cname_or_data, C.FMOD_MODE(mode), cexinfo, &sound.cptr)
+	bindExInfo(cexinfo, sound.cptr)
 	return &sound, errs[res]
 }
 
@@ -688,25 +883,117 @@ func (s *System) CreateStream(name_or_data string, mode Mode, exinfo *CreatesSou
 	defer runtime.SetFinalizer(&sound, (*Sound).Release)
 	cname_or_data := C.CString(name_or_data)
 	defer C.free(unsafe.Pointer(cname_or_data))
-	// FIX me
-	res := C.FMOD_System_CreateStream(s.cptr, cname_or_data, C.FMOD_MODE(mode), (*C.FMOD_CREATESOUNDEXINFO)(null), &sound.cptr)
+	cexinfo := exinfo.toC()
+	defer freeExInfo(cexinfo)
+	res := C.FMOD_System_CreateStream(s.cptr, cname_or_data, C.FMOD_MODE(mode), cexinfo, &sound.cptr)
+	bindExInfo(cexinfo, sound.cptr)
+	return &sound, errs[res]
+}
+
+// Loads a sound from a block of memory, or opens it for streaming from memory.
+// Unlike "System.CreateSound", the data may contain any bytes, including NUL.
+//
+// data: The encoded sound file, for example the contents of a .wav or .ogg file, or PCM data with MODE_OPENRAW.
+//
+// mode: Behaviour modifier for opening the sound. MODE_OPENMEMORY is added unless MODE_OPENMEMORY_POINT is given.
+// With MODE_OPENMEMORY FMOD duplicates the data into its own buffers.
+// With MODE_OPENMEMORY_POINT FMOD uses the memory as is, so the data is copied to C memory which lives until "Sound.Release" is called,
+// or until "System.Release" releases the sound.
+// With MODE_NONBLOCKING the copy is kept in the same way, as FMOD reads it in the background.
+//
+// exinfo: Pointer to a "CreatesSoundExInfo" which lets the user provide extended information while playing the sound. Optional. Specify nil to ignore.
+// Its Length is set to the size of data.
+func (s *System) CreateSoundFromMemory(data []byte, mode Mode, exinfo *CreatesSoundExInfo) (*Sound, error) {
+	if len(data) == 0 {
+		return nil, errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	if mode&MODE_OPENMEMORY_POINT == 0 {
+		mode |= MODE_OPENMEMORY
+	}
+	info := CreatesSoundExInfo{}
+	if exinfo != nil {
+		info = *exinfo
+	}
+	info.Length = uint32(len(data))
+
+	var sound Sound
+	defer runtime.SetFinalizer(&sound, (*Sound).Release)
+	cdata := C.CBytes(data)
+	cexinfo := info.toC()
+	defer freeExInfo(cexinfo)
+	res := C.FMOD_System_CreateSound(s.cptr, (*C.char)(cdata), C.FMOD_MODE(mode), cexinfo, &sound.cptr)
+	bindExInfo(cexinfo, sound.cptr)
+	// Non blocking loads read the data after this function has returned, so it is kept like with MODE_OPENMEMORY_POINT.
+	if res != C.FMOD_OK || mode&(MODE_OPENMEMORY_POINT|MODE_NONBLOCKING) == 0 {
+		C.free(cdata)
+	} else {
+		setSoundMemory(s.cptr, sound.cptr, cdata)
+	}
+	return &sound, errs[res]
+}
+
+// Creates a streaming sound whose PCM data is generated by Go code, for example a synthesizer or a network jitter buffer.
+// The sound is played like any other sound, with "System.PlaySound".
+//
+// spec: The format of the generated data. Samples are always generated as SOUND_FORMAT_PCMFLOAT.
+//
+// gen: Fills buf with interleaved samples, and returns how many it wrote. The rest of buf is played as silence.
+// It is called from the FMOD stream thread, so it must not block and must synchronize with the rest of the program.
+//
+// Sounds with a zero spec Length play forever, see "PCMSpec.Seek" for how they loop. The userdata of the sound is used to find the generator while the sound is created,
+// so "Sound.SetUserData" should only be used once this function has returned.
+func (s *System) CreateUserSound(spec PCMSpec, gen func(buf []float32) int) (*Sound, error) {
+	if gen == nil || spec.Rate <= 0 || spec.Channels <= 0 {
+		return nil, errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	mode := MODE_2D | MODE_OPENUSER | MODE_CREATESTREAM
+	frame := uint64(spec.Channels * 4)
+	us := &userSound{gen: gen, seek: spec.Seek}
+	length := spec.Length
+	if length == 0 {
+		// An endless sound is a looping stream as long as the byte length allows, so that it rarely wraps.
+		length = uint32(math.MaxUint32 / frame)
+		mode |= MODE_LOOP_NORMAL
+		us.channels = spec.Channels
+		us.loop = length
+	}
+	if uint64(length)*frame > math.MaxUint32 {
+		return nil, errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	info := CreatesSoundExInfo{
+		Length:           length * uint32(frame),
+		NumChannels:      spec.Channels,
+		DefaultFrequency: spec.Rate,
+		Format:           SOUND_FORMAT_PCMFLOAT,
+		DecodeBufferSize: spec.DecodeBufferSize,
+	}
+	cexinfo := info.toC()
+	defer freeExInfo(cexinfo)
+	cexinfo.pcmreadcallback = (C.FMOD_SOUND_PCMREAD_CALLBACK)(unsafe.Pointer(C.goSoundPCMRead))
+	cexinfo.pcmsetposcallback = (C.FMOD_SOUND_PCMSETPOS_CALLBACK)(unsafe.Pointer(C.goSoundPCMSetPos))
+	id := addUserSound(us)
+	*(*uintptr)(unsafe.Pointer(&cexinfo.userdata)) = id
+
+	var sound Sound
+	defer runtime.SetFinalizer(&sound, (*Sound).Release)
+	res := C.FMOD_System_CreateSound(s.cptr, nil, C.FMOD_MODE(mode), cexinfo, &sound.cptr)
+	bindUserSound(id, sound.cptr)
 	return &sound, errs[res]
 }
 
 // Creates a user defined DSP unit object to be inserted into a DSP network, for the purposes of sound filtering or sound generation.
 //
-// description: Pointer of an DSP_DESCRIPTION structure containing information about the unit to be created.
-// Some members of DSP_DESCRIPTION are referenced directly inside FMOD so the structure should be allocated statically or at least remain in memory for the lifetime of the system.
+// description: Pointer of a "DSPDesc" structure containing information about the unit to be created.
+// The description is converted once and kept for the lifetime of the process, so it should not be modified after the first call.
 //
 // A DSP unit can generate or filter incoming data.
-// The data is created or filtered through use of the read callback that is defined by the user.
-// See the definition for the DSP_DESCRIPTION structure to find out what each member means.
+// The data is created or filtered through the "DSPProcessor" returned by the New function of the description, which is called once per unit.
 // To be active, a unit must be inserted into the FMOD DSP network to be heard.
 // Use functions such as "ChannelGroup.AddDSP", "Channel.AddDSP" or "DSP.AddInput" to do this.
 func (s *System) CreateDSP(description *DSPDesc) (*DSP, error) {
 	var dsp DSP
 	defer runtime.SetFinalizer(&dsp, (*DSP).Release)
-	res := C.FMOD_System_CreateDSP(s.cptr, (*C.FMOD_DSP_DESCRIPTION)(description), &dsp.cptr)
+	res := C.FMOD_System_CreateDSP(s.cptr, description.toC(), &dsp.cptr)
 	return &dsp, errs[res]
 }
 
@@ -958,57 +1245,129 @@ func (s *System) UnlockDSP() error {
    Recording API.
 */
 
-// NOTE: Not implement yet
+// Identification information about a recording device, returned by "System.RecordDriverInfo".
+type RecordDriver struct {
+	// Enumerated driver ID.
+	ID int
+
+	// Name of the device.
+	Name string
+
+	// GUID that uniquely identifies the device.
+	Guid Guid
+
+	// Sample rate this device operates at.
+	SystemRate int
+
+	// Speaker setup this device is currently using.
+	SpeakerMode SpeakerMode
+
+	// Number of channels in the current speaker setup.
+	Channels int
+
+	// Flags that provide additional information about the driver.
+	State DriverState
+}
+
+// Whether the device is currently plugged in.
+func (d RecordDriver) Connected() bool {
+	return d.State&DRIVER_STATE_CONNECTED != 0
+}
+
+// Whether the device is the users preferred choice.
+func (d RecordDriver) Default() bool {
+	return d.State&DRIVER_STATE_DEFAULT != 0
+}
+
 // Retrieves the number of recording devices available for this output mode.
 // Use this to enumerate all recording devices possible so that the user can select one.
-func (s *System) RecordNumDrivers(numdrivers, numconnected *C.int) error {
-	//FMOD_RESULT F_API FMOD_System_GetRecordNumDrivers       (FMOD_SYSTEM *system, int *numdrivers, int *numconnected);
-	return ErrNoImpl
+//
+// Returns the number of recording drivers available, and the number of them that are currently connected.
+func (s *System) RecordNumDrivers() (int, int, error) {
+	var numdrivers, numconnected C.int
+	res := C.FMOD_System_GetRecordNumDrivers(s.cptr, &numdrivers, &numconnected)
+	return int(numdrivers), int(numconnected), errs[res]
 }
 
-// NOTE: Not implement yet
-// TODO: add more docs
-// Retrieves identification information about a sound device specified by its index, and specific to the output mode set with "System.SetOutput".
-func (s *System) RecordDriverInfo(id C.int, name *C.char, namelen C.int, guid *C.FMOD_GUID, systemrate *C.int, speakermode *C.FMOD_SPEAKERMODE, speakermodechannels *C.int, state *C.FMOD_DRIVER_STATE) error {
-	//FMOD_RESULT F_API FMOD_System_GetRecordDriverInfo       (FMOD_SYSTEM *system, int id, char *name, int namelen, FMOD_GUID *guid, int *systemrate, FMOD_SPEAKERMODE *speakermode, int *speakermodechannels, FMOD_DRIVER_STATE *state);
-	return ErrNoImpl
+// Retrieves identification information about a recording device specified by its index, and specific to the output mode set with "System.SetOutput".
+//
+// id: Index of the recording device, from 0 to "System.RecordNumDrivers" - 1.
+func (s *System) RecordDriverInfo(id int) (RecordDriver, error) {
+	var name [256]C.char
+	var guid C.FMOD_GUID
+	var systemrate, speakermodechannels C.int
+	var speakermode C.FMOD_SPEAKERMODE
+	var state C.FMOD_DRIVER_STATE
+	res := C.FMOD_System_GetRecordDriverInfo(s.cptr, C.int(id), &name[0], C.int(len(name)), &guid, &systemrate, &speakermode, &speakermodechannels, &state)
+	driver := RecordDriver{
+		ID:          id,
+		Name:        C.GoString(&name[0]),
+		Guid:        Guid(guid),
+		SystemRate:  int(systemrate),
+		SpeakerMode: SpeakerMode(speakermode),
+		Channels:    int(speakermodechannels),
+		State:       DriverState(state),
+	}
+	return driver, errs[res]
+}
+
+// Retrieves identification information about all recording devices, see "System.RecordDriverInfo".
+func (s *System) RecordDrivers() ([]RecordDriver, error) {
+	numdrivers, _, err := s.RecordNumDrivers()
+	if err != nil {
+		return nil, err
+	}
+	drivers := make([]RecordDriver, numdrivers)
+	for i := range drivers {
+		drivers[i], err = s.RecordDriverInfo(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return drivers, nil
 }
 
-// NOTE: Not implement yet
 // Retrieves the current recording position of the record buffer in PCM samples.
 //
 // id: Enumerated driver ID. This must be in a valid range delimited by "System.RecordNumDrivers".
-func (s *System) RecordPosition(id C.int, position *C.uint) error {
-	//FMOD_RESULT F_API FMOD_System_GetRecordPosition         (FMOD_SYSTEM *system, int id, unsigned int *position);
-	return ErrNoImpl
+//
+// The position will return to 0 when "System.RecordStop" is called or when a non-looping recording reaches the end.
+func (s *System) RecordPosition(id int) (uint32, error) {
+	var position C.uint
+	res := C.FMOD_System_GetRecordPosition(s.cptr, C.int(id), &position)
+	return uint32(position), errs[res]
 }
 
-// NOTE: Not implement yet
 // Starts the recording engine recording to the specified recording sound.
 //
 // id: Enumerated driver ID. This must be in a valid range delimited by "System.RecordNumDrivers".
 //
-// sound: User created sound for the user to record to.
+// sound: User created sound for the user to record to, created with MODE_OPENUSER.
 //
 // loop: Boolean flag to tell the recording engine whether to continue recording to the provided sound from the start again, after it has reached the end.
 // If this is set to true the data will be continually be overwritten once every loop.
-func (s *System) RecordStart(id C.int, sound *C.FMOD_SOUND, loop C.FMOD_BOOL) error {
-	//FMOD_RESULT F_API FMOD_System_RecordStart               (FMOD_SYSTEM *system, int id, FMOD_SOUND *sound, FMOD_BOOL loop);
-	return ErrNoImpl
+//
+// See "Recorder" for a ring buffer recording that hands out the captured samples.
+func (s *System) RecordStart(id int, sound *Sound, loop bool) error {
+	res := C.FMOD_System_RecordStart(s.cptr, C.int(id), sound.cptr, getBool(loop))
+	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Stops the recording engine from recording to the specified recording sound.
-func (s *System) RecordStop(id C.int) error {
-	//FMOD_RESULT F_API FMOD_System_RecordStop                (FMOD_SYSTEM *system, int id);
-	return ErrNoImpl
+//
+// id: Enumerated driver ID. This must be in a valid range delimited by "System.RecordNumDrivers".
+func (s *System) RecordStop(id int) error {
+	res := C.FMOD_System_RecordStop(s.cptr, C.int(id))
+	return errs[res]
 }
 
-// NOTE: Not implement yet
 // Retrieves the state of the FMOD recording API, ie if it is currently recording or not.
-func (s *System) IsRecording(id C.int, recording *C.FMOD_BOOL) error {
-	//FMOD_RESULT F_API FMOD_System_IsRecording               (FMOD_SYSTEM *system, int id, FMOD_BOOL *recording);
-	return ErrNoImpl
+//
+// id: Enumerated driver ID. This must be in a valid range delimited by "System.RecordNumDrivers".
+func (s *System) IsRecording(id int) (bool, error) {
+	var recording C.FMOD_BOOL
+	res := C.FMOD_System_IsRecording(s.cptr, C.int(id), &recording)
+	return setBool(recording), errs[res]
 }
 
 /*
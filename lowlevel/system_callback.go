@@ -0,0 +1,124 @@
+package lowlevel
+
+/*
+#include <fmod.h>
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+// Describes an error that has occurred, reported with "SYSTEM_CALLBACK_ERROR".
+type ErrorInfo struct {
+	// The error.
+	Err error
+
+	// Type of the object the function was called on.
+	InstanceType ErrorCallbackInstanceType
+
+	// Raw handle of the object the function was called on.
+	// Wrap it with "SoundFromPointer", "SystemFromPointer" or "ChannelGroupFromPointer" depending on InstanceType.
+	Instance unsafe.Pointer
+
+	// Name of the function that failed.
+	FunctionName string
+
+	// Function parameters that caused the failure.
+	FunctionParams string
+}
+
+// A system callback, delivered to the function registered with "System.SetCallback".
+// Only the fields matching Type are set.
+type SystemEvent struct {
+	// The callback type.
+	Type SystemCallbackType
+
+	// The system the callback was raised for. It is nil for "SYSTEM_CALLBACK_MEMORYALLOCATIONFAILED".
+	System *System
+
+	// Set for "SYSTEM_CALLBACK_ERROR".
+	Error *ErrorInfo
+
+	// For "SYSTEM_CALLBACK_THREADCREATED" and "SYSTEM_CALLBACK_THREADDESTROYED": the name of the thread.
+	ThreadName string
+
+	// For "SYSTEM_CALLBACK_MEMORYALLOCATIONFAILED": the file and the size of the failed allocation.
+	File string
+	Size int
+
+	// For "SYSTEM_CALLBACK_BADDSPCONNECTION": the DSPs of the connection that could not be made.
+	Target, Source *DSP
+}
+
+type systemCallback struct {
+	mask SystemCallbackType
+	fn   func(SystemEvent)
+}
+
+// Registered system callbacks, keyed by System pointer.
+var systemCallbacks = struct {
+	sync.Mutex
+	m map[uintptr]systemCallback
+}{m: make(map[uintptr]systemCallback)}
+
+func setSystemCallback(system *C.FMOD_SYSTEM, mask SystemCallbackType, fn func(SystemEvent)) {
+	systemCallbacks.Lock()
+	if fn != nil {
+		systemCallbacks.m[uintptr(unsafe.Pointer(system))] = systemCallback{mask: mask, fn: fn}
+	} else {
+		delete(systemCallbacks.m, uintptr(unsafe.Pointer(system)))
+	}
+	systemCallbacks.Unlock()
+}
+
+//export goSystemCallback
+func goSystemCallback(system *C.FMOD_SYSTEM, typ C.FMOD_SYSTEM_CALLBACK_TYPE, commanddata1, commanddata2, userdata unsafe.Pointer) C.FMOD_RESULT {
+	ev := SystemEvent{Type: SystemCallbackType(typ)}
+	var fns []func(SystemEvent)
+	systemCallbacks.Lock()
+	if system != nil {
+		if cb, ok := systemCallbacks.m[uintptr(unsafe.Pointer(system))]; ok {
+			fns = append(fns, cb.fn)
+		}
+	} else {
+		// Memory allocation failures are not tied to a system, so every interested callback is told.
+		for _, cb := range systemCallbacks.m {
+			if cb.mask&ev.Type != 0 {
+				fns = append(fns, cb.fn)
+			}
+		}
+	}
+	systemCallbacks.Unlock()
+	if len(fns) == 0 {
+		return C.FMOD_OK
+	}
+
+	if system != nil {
+		ev.System = &System{cptr: system}
+	}
+	switch ev.Type {
+	case SYSTEM_CALLBACK_ERROR:
+		info := (*C.FMOD_ERRORCALLBACK_INFO)(commanddata1)
+		ev.Error = &ErrorInfo{
+			Err:            errs[info.result],
+			InstanceType:   ErrorCallbackInstanceType(info.instancetype),
+			Instance:       info.instance,
+			FunctionName:   C.GoString(info.functionname),
+			FunctionParams: C.GoString(info.functionparams),
+		}
+	case SYSTEM_CALLBACK_THREADCREATED, SYSTEM_CALLBACK_THREADDESTROYED:
+		ev.ThreadName = C.GoString((*C.char)(commanddata2))
+	case SYSTEM_CALLBACK_MEMORYALLOCATIONFAILED:
+		ev.File = C.GoString((*C.char)(commanddata1))
+		// The command data holds the value itself, not a pointer to it.
+		ev.Size = int(uintptr(commanddata2))
+	case SYSTEM_CALLBACK_BADDSPCONNECTION:
+		ev.Target = &DSP{cptr: (*C.FMOD_DSP)(commanddata1)}
+		ev.Source = &DSP{cptr: (*C.FMOD_DSP)(commanddata2)}
+	}
+	for _, fn := range fns {
+		fn(ev)
+	}
+	return C.FMOD_OK
+}
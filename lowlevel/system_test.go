@@ -1,7 +1,15 @@
 package lowlevel
 
 import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
 	"time"
 )
 
@@ -191,6 +199,61 @@ func TestSystemPlugins(t *testing.T) {
 	t.Logf("DSP: %#v\n", dspplugins)
 	t.Logf("Output: %#v\n", outplugins)
 
+	dsps, err := system.Plugins(PLUGINTYPE_DSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dsps) != dspplugins {
+		t.Errorf("expected %d DSP plugins but got %d", dspplugins, len(dsps))
+	}
+
+	for _, plugin := range dsps {
+		if plugin.Type != PLUGINTYPE_DSP || plugin.Name == "" {
+			t.Errorf("unexpected plugin %+v", plugin)
+		}
+	}
+
+	if len(dsps) > 0 {
+		description, err := system.DSPInfoByPlugin(dsps[0].Handle)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if description.Name != dsps[0].Name {
+			t.Errorf("expected description %s but got %s", dsps[0].Name, description.Name)
+		}
+
+		dsp, err := system.CreateDSPByPlugin(dsps[0].Handle)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = dsp.Release()
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	output, err := system.OutputByPlugin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := system.PluginInfo(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Type != PLUGINTYPE_OUTPUT {
+		t.Error("expected an output plugin but got", info.Type)
+	}
+
+	_, err = system.LoadPlugin("media/missing.so", 0)
+	if err == nil {
+		t.Error("expected an error for a missing plugin")
+	}
+
 	<-done
 }
 
@@ -482,3 +545,637 @@ func TestSystemAdvancedSettings(t *testing.T) {
 	<-done
 }
 */
+
+func TestSystemErrorCallback(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var info *ErrorInfo
+	err = system.SetCallback(SYSTEM_CALLBACK_ERROR, func(ev SystemEvent) {
+		if ev.Type == SYSTEM_CALLBACK_ERROR {
+			info = ev.Error
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// API errors are reported on the calling thread, before the function returns
+	_, err = system.CreateSound("media/missing.wav", MODE_DEFAULT, nil)
+	if err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+
+	if info == nil {
+		t.Fatal("expected an error callback")
+	}
+
+	if info.Err != err {
+		t.Errorf("expected %v but got %v", err, info.Err)
+	}
+
+	if info.FunctionName == "" {
+		t.Error("expected the name of the failing function")
+	}
+
+	err = system.SetCallback(0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+func TestSystemSetFS(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.SetFS(os.DirFS("media"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Names are resolved relative to the root of the file system
+	censor, err := system.CreateSound("censor.wav", MODE_CREATESAMPLE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	length, err := censor.Length(TIMEUNIT_PCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if length == 0 {
+		t.Error("expected decoded sample data")
+	}
+
+	_, err = system.CreateSound("media/censor.wav", MODE_CREATESAMPLE, nil)
+	if err == nil {
+		t.Error("expected an error for a file outside of the file system")
+	}
+
+	_, err = system.CreateSound("/", MODE_CREATESAMPLE, nil)
+	if err == nil {
+		t.Error("expected an error for the root of the file system")
+	}
+
+	err = system.SetFS(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+func TestSystemSetFSAsync(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob("media/*.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := fstest.MapFS{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fsys[filepath.Base(file)] = &fstest.MapFile{Data: data}
+	}
+
+	err = system.SetFSAsync(fsys, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name := range fsys {
+		sound, err := system.CreateSound(name, MODE_CREATESAMPLE, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		length, err := sound.Length(TIMEUNIT_PCM)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if length == 0 {
+			t.Error("expected decoded sample data for", name)
+		}
+
+		err = sound.Release()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = system.CreateSound("missing.wav", MODE_CREATESAMPLE, nil)
+	if err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	err = system.SetFS(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+func TestSystemAttachFileSystem(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var accesses []FileAccess
+	err = system.AttachFileSystem(func(access FileAccess) {
+		mu.Lock()
+		accesses = append(accesses, access)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	censor, err := system.CreateSound("media/censor.wav", MODE_CREATESAMPLE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = censor.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.AttachFileSystem(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	var opened, read, closed int
+	for _, access := range accesses {
+		if access.Name != "media/censor.wav" {
+			t.Errorf("unexpected access %+v", access)
+		}
+		switch access.Op {
+		case FILE_OPEN:
+			opened++
+		case FILE_READ:
+			read += access.Size
+		case FILE_CLOSE:
+			closed++
+		}
+	}
+	mu.Unlock()
+
+	if opened != 1 || closed != 1 {
+		t.Errorf("expected the file to be opened and closed once but got %d and %d", opened, closed)
+	}
+
+	if read == 0 {
+		t.Error("expected reads to be logged")
+	}
+
+	<-done
+}
+
+type gainProcessor struct {
+	gain float64
+}
+
+func (p *gainProcessor) Read(in, out []float32, channels int) {
+	for i := range out {
+		out[i] = in[i] * float32(p.gain)
+	}
+}
+
+func (p *gainProcessor) Reset()                 {}
+func (p *gainProcessor) SetPosition(pos uint32) {}
+func (p *gainProcessor) ShouldIProcess(inputsIdle bool, length, channels int) bool {
+	return !inputsIdle
+}
+func (p *gainProcessor) SetParameter(index int, value interface{}) { p.gain = value.(float64) }
+
+func TestSystemCreateDSP(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desc := &DSPDesc{
+		Name:             "Go Gain",
+		NumInputBuffers:  1,
+		NumOutputBuffers: 1,
+		Parameters: []DSPParameterDesc{
+			NewDSPParameterFloat("Gain", "x", "Linear gain", 0, 2, 0.5),
+		},
+		New: func() DSPProcessor { return new(gainProcessor) },
+	}
+
+	dsp, err := system.CreateDSP(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := dsp.ParameterInfo(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Type != DSP_PARAMETER_TYPE_FLOAT || info.Name != "Gain" || info.Float.Max != 2 {
+		t.Errorf("unexpected parameter info %+v", info)
+	}
+
+	err = dsp.SetParameterFloat(0, 1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, _, err := dsp.ParameterFloat(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != 1.5 {
+		t.Errorf("expected gain 1.5, got %v", value)
+	}
+
+	if p, ok := dsp.Processor().(*gainProcessor); !ok || p.gain != 1.5 {
+		t.Error("expected the processor to be told about the parameter change")
+	}
+
+	err = dsp.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+type paramProcessor struct {
+	mu     sync.Mutex
+	values map[int]interface{}
+}
+
+func (p *paramProcessor) Read(in, out []float32, channels int) { copy(out, in) }
+func (p *paramProcessor) Reset()                               {}
+func (p *paramProcessor) SetPosition(pos uint32)               {}
+func (p *paramProcessor) ShouldIProcess(inputsIdle bool, length, channels int) bool {
+	return !inputsIdle
+}
+func (p *paramProcessor) SetParameter(index int, value interface{}) {
+	p.mu.Lock()
+	p.values[index] = value
+	p.mu.Unlock()
+}
+
+func TestSystemCreateDSPParameters(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	processor := &paramProcessor{values: make(map[int]interface{})}
+	desc := &DSPDesc{
+		Name:             "Go Parameters",
+		NumInputBuffers:  1,
+		NumOutputBuffers: 1,
+		Parameters: []DSPParameterDesc{
+			NewDSPParameterInt("Quality", "", "Processing quality", 0, 2, 1, false, "Low", "Medium", "High"),
+			NewDSPParameterInt("Steps", "", "Number of steps", 1, 8, 4, true),
+			NewDSPParameterBool("Sidechain", "", "Use the sidechain input", true, "Off", "Sidechain"),
+			NewDSPParameterData("Curve", "", "Transfer curve", 0),
+		},
+		New: func() DSPProcessor { return processor },
+	}
+
+	dsp, err := system.CreateDSP(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := dsp.NumParameters()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != len(desc.Parameters) {
+		t.Errorf("expected %d parameters but got %d", len(desc.Parameters), count)
+	}
+
+	for i, want := range desc.Parameters {
+		info, err := dsp.ParameterInfo(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(info, want) {
+			t.Errorf("expected parameter %d to be described as %+v but got %+v", i, want, info)
+		}
+	}
+
+	// Defaults are reported with their value names.
+	quality, name, err := dsp.ParameterInt(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if quality != 1 || name != "Medium" {
+		t.Errorf("expected the default quality 1 (Medium) but got %d (%s)", quality, name)
+	}
+
+	err = dsp.SetParameterInt(0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quality, name, err = dsp.ParameterInt(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if quality != 2 || name != "High" {
+		t.Errorf("expected quality 2 (High) but got %d (%s)", quality, name)
+	}
+
+	err = dsp.SetParameterInt(1, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps, name, err := dsp.ParameterInt(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if steps != 6 || name != "6" {
+		t.Errorf("expected 6 steps without a value name but got %d (%s)", steps, name)
+	}
+
+	err = dsp.SetParameterBool(2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sidechain, name, err := dsp.ParameterBool(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sidechain || name != "Off" {
+		t.Errorf("expected the sidechain to be off but got %v (%s)", sidechain, name)
+	}
+
+	curve := []byte{0, 64, 128, 255}
+	err = dsp.SetParameterData(3, curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _, err := dsp.ParameterData(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, curve) {
+		t.Errorf("expected data %v but got %v", curve, data)
+	}
+
+	processor.mu.Lock()
+	if processor.values[0] != 2 || processor.values[1] != 6 || processor.values[2] != false || !bytes.Equal(processor.values[3].([]byte), curve) {
+		t.Errorf("expected the processor to be told about every change but got %v", processor.values)
+	}
+	processor.mu.Unlock()
+
+	// Values of the wrong type or index are rejected.
+	_, _, err = dsp.ParameterFloat(0)
+	if err == nil {
+		t.Error("expected an error for a float read of an int parameter")
+	}
+
+	err = dsp.SetParameterInt(len(desc.Parameters), 1)
+	if err == nil {
+		t.Error("expected an error for an out of range parameter")
+	}
+
+	err = dsp.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+type toneProcessor struct {
+	reads     int32
+	withInput int32
+	channels  int32
+}
+
+func (p *toneProcessor) Read(in, out []float32, channels int) {
+	atomic.AddInt32(&p.reads, 1)
+	if in != nil {
+		atomic.AddInt32(&p.withInput, 1)
+	}
+	atomic.StoreInt32(&p.channels, int32(channels))
+	for i := range out {
+		out[i] = 0.25
+	}
+}
+
+func (p *toneProcessor) Reset()                 {}
+func (p *toneProcessor) SetPosition(pos uint32) {}
+func (p *toneProcessor) ShouldIProcess(inputsIdle bool, length, channels int) bool {
+	return true
+}
+
+func TestSystemCreateDSPGenerator(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	processor := new(toneProcessor)
+	desc := &DSPDesc{
+		Name:             "Go Tone",
+		NumInputBuffers:  0,
+		NumOutputBuffers: 1,
+		New:              func() DSPProcessor { return processor },
+	}
+
+	dsp, err := system.CreateDSP(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel, err := system.PlayDSP(dsp, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 20 && atomic.LoadInt32(&processor.reads) == 0; i++ {
+		err = system.Update()
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&processor.reads) == 0 {
+		t.Fatal("expected the generator to be read")
+	}
+
+	if atomic.LoadInt32(&processor.withInput) != 0 {
+		t.Error("expected a nil input buffer for a generator")
+	}
+
+	if atomic.LoadInt32(&processor.channels) <= 0 {
+		t.Error("expected the generator to be given output channels")
+	}
+
+	err = channel.Stop()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = dsp.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+func TestSystemRecord(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { <-done }()
+
+	drivers, err := system.RecordDrivers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := -1
+	for _, driver := range drivers {
+		if driver.Connected() {
+			id = driver.ID
+			break
+		}
+	}
+	if id < 0 {
+		t.Skip("no recording device connected")
+	}
+
+	recorder, err := system.NewRecorder(id, SOUND_FORMAT_PCM16, 500*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	chunks := make(chan []float32, 64)
+	err = recorder.StreamFloat32(ctx, chunks)
+	if err != context.DeadlineExceeded {
+		t.Fatal(err)
+	}
+
+	close(chunks)
+	for chunk := range chunks {
+		if len(chunk)%recorder.Channels() != 0 {
+			t.Errorf("expected whole frames, got %d samples", len(chunk))
+		}
+	}
+
+	recording, err := system.IsRecording(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recording {
+		t.Error("expected recording to stop with the stream")
+	}
+}
+
+func TestRecorderRingBuffer(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const length = 64
+	if d := ringDistance(length-2, 2, length); d != 4 {
+		t.Error("expected a distance of 4 across the end but got", d)
+	}
+
+	if d := ringDistance(10, 20, length); d != 10 {
+		t.Error("expected a distance of 10 but got", d)
+	}
+
+	if d := ringDistance(20, 20, length); d != 0 {
+		t.Error("expected a distance of 0 but got", d)
+	}
+
+	// The ring buffer of a recorder, without a recording device.
+	sound, err := system.createRecordSound(8000, 1, SOUND_FORMAT_PCM16, length)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &Recorder{
+		system: system,
+		driver: RecordDriver{SystemRate: 8000, Channels: 1},
+		sound:  sound,
+		format: SOUND_FORMAT_PCM16,
+		length: length,
+	}
+
+	lock, err := sound.Lock(0, length*2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samples, _ := lock.Int16()
+	for i := range samples {
+		samples[i] = int16(i)
+	}
+
+	err = lock.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := recorder.read(length-2, ringDistance(length-2, 2, length))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := int16Samples(data, SOUND_FORMAT_PCM16)
+	want := []int16{length - 2, length - 1, 0, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v across the end of the ring buffer but got %v", want, got)
+	}
+
+	err = sound.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
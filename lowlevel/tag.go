@@ -2,10 +2,15 @@ package lowlevel
 
 /*
 #include <fmod_common.h>
-#include <stdlib.h>
 */
 import "C"
-import "unsafe"
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"unicode/utf16"
+)
 
 type TagType C.FMOD_TAGTYPE
 
@@ -40,37 +45,106 @@ const (
 	TAGDATATYPE_FORCEINT                   = C.FMOD_TAGDATATYPE_FORCEINT
 )
 
+// Describes a piece of tag data, returned by "Sound.Tag", "Sound.TagByName" and "Sound.Tags".
 type Tag struct {
-	Type     TagType
+	// The type of this tag.
+	Type TagType
+
+	// The type of data that this tag contains.
 	DataType TagDataType
-	Name     string
-	Data     unsafe.Pointer
-	DataLen  uint32
-	Updated  bool
+
+	// The name of this tag i.e. "TITLE", "ARTIST" etc.
+	Name string
+
+	// The decoded tag data: a string for the string data types, an int64 for TAGDATATYPE_INT,
+	// a float64 for TAGDATATYPE_FLOAT and a []byte otherwise.
+	Value interface{}
+
+	// A copy of the raw tag data.
+	Data []byte
+
+	// True if this tag has been updated since last being accessed with "Sound.Tag", for example when a netstream changes song.
+	Updated bool
 }
 
 func NewTag() Tag {
 	return Tag{}
 }
 
+// String returns the value of a string tag, or "" for other data types.
+func (t *Tag) String() string {
+	str, _ := t.Value.(string)
+	return str
+}
+
 func (t *Tag) fromC(ct C.FMOD_TAG) {
 	t.Type = TagType(ct._type)
 	t.DataType = TagDataType(ct.datatype)
 	t.Name = C.GoString(ct.name)
-	t.Data = unsafe.Pointer(ct.data)
-	t.DataLen = uint32(ct.datalen)
+	t.Data = C.GoBytes(ct.data, C.int(ct.datalen))
+	t.Value = decodeTagData(t.DataType, t.Data)
 	t.Updated = setBool(ct.updated)
 }
 
-func (t *Tag) toC() C.FMOD_TAG {
-	var ctag C.FMOD_TAG
-	ctag._type = C.FMOD_TAGTYPE(t.Type)
-	ctag.datatype = C.FMOD_TAGDATATYPE(t.DataType)
-	name := C.CString(t.Name)
-	C.free(unsafe.Pointer(name))
-	ctag.name = name
-	ctag.data = t.Data
-	ctag.datalen = C.uint(t.DataLen)
-	ctag.updated = getBool(t.Updated)
-	return ctag
+// decodeTagData converts tag data to the Go value matching its data type.
+func decodeTagData(datatype TagDataType, data []byte) interface{} {
+	switch datatype {
+	case TAGDATATYPE_STRING:
+		// ISO-8859-1, which maps directly to the first Unicode code points.
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return trimNUL(string(runes))
+	case TAGDATATYPE_STRING_UTF8:
+		return trimNUL(string(bytes.TrimPrefix(data, []byte{0xef, 0xbb, 0xbf})))
+	case TAGDATATYPE_STRING_UTF16:
+		return decodeUTF16(data, binary.LittleEndian)
+	case TAGDATATYPE_STRING_UTF16BE:
+		return decodeUTF16(data, binary.BigEndian)
+	case TAGDATATYPE_INT:
+		switch len(data) {
+		case 1:
+			return int64(int8(data[0]))
+		case 2:
+			return int64(int16(binary.LittleEndian.Uint16(data)))
+		case 4:
+			return int64(int32(binary.LittleEndian.Uint32(data)))
+		case 8:
+			return int64(binary.LittleEndian.Uint64(data))
+		}
+	case TAGDATATYPE_FLOAT:
+		switch len(data) {
+		case 4:
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(data)))
+		case 8:
+			return math.Float64frombits(binary.LittleEndian.Uint64(data))
+		}
+	}
+	return data
+}
+
+// decodeUTF16 decodes a UTF-16 string in the given byte order. A byte order mark overrides the order.
+func decodeUTF16(data []byte, order binary.ByteOrder) string {
+	if len(data) >= 2 {
+		switch {
+		case data[0] == 0xff && data[1] == 0xfe:
+			order, data = binary.LittleEndian, data[2:]
+		case data[0] == 0xfe && data[1] == 0xff:
+			order, data = binary.BigEndian, data[2:]
+		}
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return trimNUL(string(utf16.Decode(units)))
+}
+
+// trimNUL cuts a string at its first NUL character.
+func trimNUL(s string) string {
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		return s[:i]
+	}
+	return s
 }
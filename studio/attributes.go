@@ -0,0 +1,47 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+import "github.com/theaidem/fmod/lowlevel"
+
+// Structure describing a position, velocity and orientation.
+// Used by "EventInstance.Set3DAttributes" and "System.SetListenerAttributes".
+type Attributes3D struct {
+	// The position of the object in world space, measured in distance units.
+	Position lowlevel.Vector
+
+	// The velocity of the object measured in distance units per second.
+	Velocity lowlevel.Vector
+
+	// The forwards orientation of the object. This vector must be of unit length (1.0) and perpendicular to the up vector.
+	Forward lowlevel.Vector
+
+	// The upwards orientation of the object. This vector must be of unit length (1.0) and perpendicular to the forward vector.
+	Up lowlevel.Vector
+}
+
+func vectorToC(v lowlevel.Vector) C.FMOD_VECTOR {
+	return C.FMOD_VECTOR{x: C.float(v.X), y: C.float(v.Y), z: C.float(v.Z)}
+}
+
+func vectorFromC(cv C.FMOD_VECTOR) lowlevel.Vector {
+	return lowlevel.Vector{X: float32(cv.x), Y: float32(cv.y), Z: float32(cv.z)}
+}
+
+func (a *Attributes3D) fromC(ca C.FMOD_3D_ATTRIBUTES) {
+	a.Position = vectorFromC(ca.position)
+	a.Velocity = vectorFromC(ca.velocity)
+	a.Forward = vectorFromC(ca.forward)
+	a.Up = vectorFromC(ca.up)
+}
+
+func (a *Attributes3D) toC() C.FMOD_3D_ATTRIBUTES {
+	var ca C.FMOD_3D_ATTRIBUTES
+	ca.position = vectorToC(a.Position)
+	ca.velocity = vectorToC(a.Velocity)
+	ca.forward = vectorToC(a.Forward)
+	ca.up = vectorToC(a.Up)
+	return ca
+}
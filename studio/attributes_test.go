@@ -0,0 +1,100 @@
+package studio
+
+import (
+	"testing"
+
+	"github.com/theaidem/fmod/lowlevel"
+)
+
+func TestSystemListeners(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.SetNumListeners(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.SetListenerWeight(1, 0.25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attributes := Attributes3D{
+		Position: lowlevel.Vector{X: 1, Y: 2, Z: 3},
+		Velocity: lowlevel.Vector{X: 0.5},
+		Forward:  lowlevel.Vector{Z: 1},
+		Up:       lowlevel.Vector{Y: 1},
+	}
+	err = system.SetListenerAttributes(1, attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.FlushCommands()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := system.NumListeners()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 2 {
+		t.Error("expected 2 listeners but got", count)
+	}
+
+	weight, err := system.ListenerWeight(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if weight != 0.25 {
+		t.Error("expected a weight of 0.25 but got", weight)
+	}
+
+	got, err := system.ListenerAttributes(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != attributes {
+		t.Errorf("expected attributes %+v but got %+v", attributes, got)
+	}
+
+	// Listener indices are checked against the number of listeners.
+	err = system.SetListenerWeight(2, 1)
+	if err == nil {
+		t.Error("expected an error for an out of range listener")
+	}
+
+	_, err = system.ListenerWeight(-1)
+	if err == nil {
+		t.Error("expected an error for an out of range listener")
+	}
+
+	err = system.SetListenerAttributes(2, attributes)
+	if err == nil {
+		t.Error("expected an error for an out of range listener")
+	}
+
+	_, err = system.ListenerAttributes(8)
+	if err == nil {
+		t.Error("expected an error for an out of range listener")
+	}
+
+	err = system.SetNumListeners(0)
+	if err == nil {
+		t.Error("expected an error without listeners")
+	}
+
+	err = system.SetNumListeners(9)
+	if err == nil {
+		t.Error("expected an error for more listeners than FMOD supports")
+	}
+
+	<-done
+}
@@ -0,0 +1,225 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+import (
+	"context"
+	"time"
+)
+
+// Represents a loaded Studio bank.
+// Banks are loaded with "System.LoadBankFile" or "System.LoadBankMemory".
+type Bank struct {
+	cptr *C.FMOD_STUDIO_BANK
+}
+
+/*
+   'Bank' API
+*/
+
+// Checks that the Bank reference is valid.
+func (b *Bank) IsValid() bool {
+	return setBool(C.FMOD_Studio_Bank_IsValid(b.cptr))
+}
+
+// Retrieves the GUID of the bank.
+func (b *Bank) ID() (GUID, error) {
+	var id C.FMOD_GUID
+	res := C.FMOD_Studio_Bank_GetID(b.cptr, &id)
+	return guidFromC(id), errs[res]
+}
+
+// Retrieves the path of the bank, for example "bank:/Weapons".
+// The strings bank must be loaded for this function to succeed.
+func (b *Bank) Path() (string, error) {
+	return getString(func(buf *C.char, size C.int, retrieved *C.int) C.FMOD_RESULT {
+		return C.FMOD_Studio_Bank_GetPath(b.cptr, buf, size, retrieved)
+	})
+}
+
+// Unloads the bank.
+// This will destroy all objects created from the bank, unload all sample data inside the bank, and invalidate all API handles referring to the bank.
+func (b *Bank) Unload() error {
+	res := C.FMOD_Studio_Bank_Unload(b.cptr)
+	return errs[res]
+}
+
+// Retrieves the loading state of the bank.
+// For banks loaded with "LOAD_BANK_NONBLOCKING" the state is "LOADING_STATE_LOADING" until the load completes.
+// If the load failed, the state is "LOADING_STATE_ERROR" and the returned error describes the failure.
+func (b *Bank) LoadingState() (LoadingState, error) {
+	var state C.FMOD_STUDIO_LOADING_STATE
+	res := C.FMOD_Studio_Bank_GetLoadingState(b.cptr, &state)
+	return LoadingState(state), errs[res]
+}
+
+/*
+   Enumeration.
+*/
+
+// Retrieves the number of event descriptions in the bank.
+// This function counts the events which were added to the bank by the sound designer.
+func (b *Bank) EventCount() (int, error) {
+	var count C.int
+	res := C.FMOD_Studio_Bank_GetEventCount(b.cptr, &count)
+	return int(count), errs[res]
+}
+
+// Retrieves the event descriptions in the bank.
+func (b *Bank) EventList() ([]*EventDescription, error) {
+	count, err := b.EventCount()
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	carray := make([]*C.FMOD_STUDIO_EVENTDESCRIPTION, count)
+	var retrieved C.int
+	res := C.FMOD_Studio_Bank_GetEventList(b.cptr, &carray[0], C.int(count), &retrieved)
+	events := make([]*EventDescription, int(retrieved))
+	for i := range events {
+		events[i] = &EventDescription{cptr: carray[i]}
+	}
+	return events, errs[res]
+}
+
+// Retrieves the number of buses in the bank.
+func (b *Bank) BusCount() (int, error) {
+	var count C.int
+	res := C.FMOD_Studio_Bank_GetBusCount(b.cptr, &count)
+	return int(count), errs[res]
+}
+
+// Retrieves the buses in the bank.
+func (b *Bank) BusList() ([]*Bus, error) {
+	count, err := b.BusCount()
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	carray := make([]*C.FMOD_STUDIO_BUS, count)
+	var retrieved C.int
+	res := C.FMOD_Studio_Bank_GetBusList(b.cptr, &carray[0], C.int(count), &retrieved)
+	buses := make([]*Bus, int(retrieved))
+	for i := range buses {
+		buses[i] = &Bus{cptr: carray[i]}
+	}
+	return buses, errs[res]
+}
+
+// Retrieves the number of VCAs in the bank.
+func (b *Bank) VCACount() (int, error) {
+	var count C.int
+	res := C.FMOD_Studio_Bank_GetVCACount(b.cptr, &count)
+	return int(count), errs[res]
+}
+
+// Retrieves the VCAs in the bank.
+func (b *Bank) VCAList() ([]*VCA, error) {
+	count, err := b.VCACount()
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	carray := make([]*C.FMOD_STUDIO_VCA, count)
+	var retrieved C.int
+	res := C.FMOD_Studio_Bank_GetVCAList(b.cptr, &carray[0], C.int(count), &retrieved)
+	vcas := make([]*VCA, int(retrieved))
+	for i := range vcas {
+		vcas[i] = &VCA{cptr: carray[i]}
+	}
+	return vcas, errs[res]
+}
+
+// Retrieves the number of string table entries in the bank.
+// Only the strings bank (usually "Master Bank.strings.bank") contains string table entries.
+func (b *Bank) StringCount() (int, error) {
+	var count C.int
+	res := C.FMOD_Studio_Bank_GetStringCount(b.cptr, &count)
+	return int(count), errs[res]
+}
+
+// Retrieves a string table entry.
+//
+// index: String table entry index, from 0 to "Bank.StringCount" - 1.
+//
+// Returns the GUID and the path of the object, for example "event:/UI/Cancel".
+func (b *Bank) StringInfo(index int) (GUID, string, error) {
+	var id C.FMOD_GUID
+	path, err := getString(func(buf *C.char, size C.int, retrieved *C.int) C.FMOD_RESULT {
+		return C.FMOD_Studio_Bank_GetStringInfo(b.cptr, C.int(index), &id, buf, size, retrieved)
+	})
+	return guidFromC(id), path, err
+}
+
+/*
+   Sample data.
+*/
+
+// Loads non-streaming sample data for all events in the bank.
+// Loading is asynchronous, use "Bank.SampleLoadingState" to query its progress.
+// Sample data stays loaded until "Bank.UnloadSampleData" is called, even if no event is playing.
+func (b *Bank) LoadSampleData() error {
+	res := C.FMOD_Studio_Bank_LoadSampleData(b.cptr)
+	return errs[res]
+}
+
+// Unloads non-streaming sample data for all events in the bank.
+// Sample data still used by playing instances or by event descriptions that loaded it stays loaded until they release it.
+func (b *Bank) UnloadSampleData() error {
+	res := C.FMOD_Studio_Bank_UnloadSampleData(b.cptr)
+	return errs[res]
+}
+
+// Retrieves the loading state of the samples in the bank.
+// The state reflects explicit loads made with "Bank.LoadSampleData" only.
+func (b *Bank) SampleLoadingState() (LoadingState, error) {
+	var state C.FMOD_STUDIO_LOADING_STATE
+	res := C.FMOD_Studio_Bank_GetSampleLoadingState(b.cptr, &state)
+	return LoadingState(state), errs[res]
+}
+
+// Interval between two loading state queries made by "Bank.WaitLoaded" and "Bank.WaitSampleDataLoaded".
+const loadingPollInterval = 10 * time.Millisecond
+
+// Blocks until the bank has finished loading, the load has failed, or ctx is done.
+// It is meant for banks loaded with "LOAD_BANK_NONBLOCKING", and returns ctx.Err() if ctx expires first.
+//
+// Loading progresses through "System.Update", so the caller must keep updating the system from another goroutine while waiting.
+func (b *Bank) WaitLoaded(ctx context.Context) error {
+	return waitLoaded(ctx, b.LoadingState, false)
+}
+
+// Blocks until the sample data requested with "Bank.LoadSampleData" has finished loading, the load has failed, or ctx is done.
+// See "Bank.WaitLoaded".
+//
+// The sample loading state may still be "LOADING_STATE_UNLOADED" until the request has been processed, so use a ctx with a deadline.
+func (b *Bank) WaitSampleDataLoaded(ctx context.Context) error {
+	return waitLoaded(ctx, b.SampleLoadingState, true)
+}
+
+// waitLoaded polls state until it reports "LOADING_STATE_LOADED".
+// When queued is set, an unloaded state is treated as a request that has not been processed yet instead of a failure.
+func waitLoaded(ctx context.Context, state func() (LoadingState, error), queued bool) error {
+	ticker := time.NewTicker(loadingPollInterval)
+	defer ticker.Stop()
+	for {
+		s, err := state()
+		if err != nil {
+			return err
+		}
+		switch s {
+		case LOADING_STATE_LOADED:
+			return nil
+		case LOADING_STATE_ERROR:
+			return errs[C.FMOD_ERR_INTERNAL]
+		case LOADING_STATE_UNLOADING, LOADING_STATE_UNLOADED:
+			if !queued {
+				return errs[C.FMOD_ERR_NOTREADY]
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package studio
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// updateInBackground calls "System.Update" from another goroutine, as loading progresses through it.
+// The returned function stops the updates, and must be called before the system is released.
+func updateInBackground(system *System) (stop func()) {
+	quit := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(loadingPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+				system.Update()
+			}
+		}
+	}()
+	return func() {
+		close(quit)
+		<-stopped
+	}
+}
+
+func TestBankWaitLoaded(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { <-done }()
+
+	files, err := filepath.Glob("media/*.bank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no Studio banks in media")
+	}
+
+	stop := updateInBackground(system)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, file := range files {
+		bank, err := system.LoadBankFile(file, LOAD_BANK_NONBLOCKING)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = bank.WaitLoaded(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		state, err := bank.LoadingState()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if state != LOADING_STATE_LOADED {
+			t.Error("expected LOADING_STATE_LOADED but got", state)
+		}
+
+		err = bank.LoadSampleData()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = bank.WaitSampleDataLoaded(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBankWaitLoadedMissing(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := updateInBackground(system)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A non blocking load may report the missing file right away, or through the loading state.
+	bank, err := system.LoadBankFile("media/missing.bank", LOAD_BANK_NONBLOCKING)
+	if err == nil {
+		err = bank.WaitLoaded(ctx)
+		if err == nil || err == context.DeadlineExceeded {
+			t.Error("expected a loading error for a missing bank but got", err)
+		}
+	}
+
+	stop()
+	<-done
+}
+
+func TestBankWaitLoadedTimeout(t *testing.T) {
+	loading := func() (LoadingState, error) {
+		return LOADING_STATE_LOADING, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := waitLoaded(ctx, loading, false)
+	if err != context.DeadlineExceeded {
+		t.Error("expected context.DeadlineExceeded while loading but got", err)
+	}
+
+	// A sample data request which has not been processed yet.
+	unloaded := func() (LoadingState, error) {
+		return LOADING_STATE_UNLOADED, nil
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = waitLoaded(ctx, unloaded, true)
+	if err != context.DeadlineExceeded {
+		t.Error("expected context.DeadlineExceeded while queued but got", err)
+	}
+
+	err = waitLoaded(context.Background(), unloaded, false)
+	if err == nil {
+		t.Error("expected an error for an unloaded bank")
+	}
+
+	calls := 0
+	eventually := func() (LoadingState, error) {
+		calls++
+		if calls < 3 {
+			return LOADING_STATE_LOADING, nil
+		}
+		return LOADING_STATE_LOADED, nil
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = waitLoaded(ctx, eventually, false)
+	if err != nil {
+		t.Error("expected the bank to load but got", err)
+	}
+}
@@ -0,0 +1,124 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/theaidem/fmod/lowlevel"
+)
+
+// Represents a global mixer bus.
+// Buses are retrieved with "System.Bus".
+type Bus struct {
+	cptr *C.FMOD_STUDIO_BUS
+}
+
+/*
+   'Bus' API
+*/
+
+// Checks that the Bus reference is valid.
+func (b *Bus) IsValid() bool {
+	return setBool(C.FMOD_Studio_Bus_IsValid(b.cptr))
+}
+
+// Retrieves the GUID of the bus.
+func (b *Bus) ID() (GUID, error) {
+	var id C.FMOD_GUID
+	res := C.FMOD_Studio_Bus_GetID(b.cptr, &id)
+	return guidFromC(id), errs[res]
+}
+
+// Retrieves the path of the bus, for example "bus:/SFX/Ambience".
+// The strings bank must be loaded for this function to succeed.
+func (b *Bus) Path() (string, error) {
+	return getString(func(buf *C.char, size C.int, retrieved *C.int) C.FMOD_RESULT {
+		return C.FMOD_Studio_Bus_GetPath(b.cptr, buf, size, retrieved)
+	})
+}
+
+/*
+   Playback control.
+*/
+
+// Sets the volume (fader level) of the bus.
+//
+// volume: Linear volume level, default = 1.0.
+func (b *Bus) SetVolume(volume float64) error {
+	res := C.FMOD_Studio_Bus_SetFaderLevel(b.cptr, C.float(volume))
+	return errs[res]
+}
+
+// Retrieves the volume (fader level) of the bus.
+func (b *Bus) Volume() (float64, error) {
+	var volume C.float
+	res := C.FMOD_Studio_Bus_GetFaderLevel(b.cptr, &volume)
+	return float64(volume), errs[res]
+}
+
+// Sets the pause state.
+// Pausing a bus pauses all events routed into it.
+func (b *Bus) SetPaused(paused bool) error {
+	res := C.FMOD_Studio_Bus_SetPaused(b.cptr, getBool(paused))
+	return errs[res]
+}
+
+// Retrieves the pause state.
+func (b *Bus) IsPaused() (bool, error) {
+	var paused C.FMOD_BOOL
+	res := C.FMOD_Studio_Bus_GetPaused(b.cptr, &paused)
+	return setBool(paused), errs[res]
+}
+
+// Sets the mute state.
+// Mute is an additional control for volume, the effect of which is equivalent to setting the volume to zero.
+func (b *Bus) SetMute(mute bool) error {
+	res := C.FMOD_Studio_Bus_SetMute(b.cptr, getBool(mute))
+	return errs[res]
+}
+
+// Retrieves the mute state.
+func (b *Bus) Mute() (bool, error) {
+	var mute C.FMOD_BOOL
+	res := C.FMOD_Studio_Bus_GetMute(b.cptr, &mute)
+	return setBool(mute), errs[res]
+}
+
+// Stops all event instances that are routed into the bus.
+//
+// mode: Stop mode, see "StopMode".
+func (b *Bus) StopAllEvents(mode StopMode) error {
+	res := C.FMOD_Studio_Bus_StopAllEvents(b.cptr, C.FMOD_STUDIO_STOP_MODE(mode))
+	return errs[res]
+}
+
+/*
+   Low level API access.
+*/
+
+// Retrieves the low level ChannelGroup for the bus.
+//
+// By default the channel group only exists while events are routed into the bus.
+// Use "Bus.LockChannelGroup" to force it to be created and kept alive.
+// The returned ChannelGroup is owned by FMOD Studio and must not be released.
+func (b *Bus) ChannelGroup() (*lowlevel.ChannelGroup, error) {
+	var group *C.FMOD_CHANNELGROUP
+	res := C.FMOD_Studio_Bus_GetChannelGroup(b.cptr, &group)
+	return lowlevel.ChannelGroupFromPointer(unsafe.Pointer(group)), errs[res]
+}
+
+// Locks the channel group of the bus, forcing the ChannelGroup to be created and stay alive until "Bus.UnlockChannelGroup" is called.
+// The channel group is created asynchronously, so it is only available after the next "System.Update" (or "System.FlushCommands").
+func (b *Bus) LockChannelGroup() error {
+	res := C.FMOD_Studio_Bus_LockChannelGroup(b.cptr)
+	return errs[res]
+}
+
+// Unlocks the channel group of the bus, allowing the system to destroy it when no events are routed into the bus.
+func (b *Bus) UnlockChannelGroup() error {
+	res := C.FMOD_Studio_Bus_UnlockChannelGroup(b.cptr)
+	return errs[res]
+}
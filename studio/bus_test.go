@@ -0,0 +1,66 @@
+package studio
+
+import "testing"
+
+func TestSystemBusNotFound(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := ParseGUID("{2a3e48e6-94fc-4363-9468-33d2dd4d7b00}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without banks, not even the master bus exists.
+	for _, path := range []string{"bus:/", "bus:/Missing"} {
+		_, err = system.Bus(path)
+		if err != ErrNotFound {
+			t.Errorf("%s: expected ErrNotFound but got %v", path, err)
+		}
+	}
+
+	_, err = system.BusByID(id)
+	if err != ErrNotFound {
+		t.Error("expected ErrNotFound for a missing bus ID but got", err)
+	}
+
+	_, err = system.VCA("vca:/Missing")
+	if err != ErrNotFound {
+		t.Error("expected ErrNotFound for a missing VCA but got", err)
+	}
+
+	_, err = system.VCAByID(id)
+	if err != ErrNotFound {
+		t.Error("expected ErrNotFound for a missing VCA ID but got", err)
+	}
+
+	// Handles which were never retrieved are rejected.
+	var bus Bus
+	if bus.IsValid() {
+		t.Error("expected an invalid bus")
+	}
+
+	err = bus.SetVolume(0.5)
+	if err == nil {
+		t.Error("expected an error for an invalid bus")
+	}
+
+	_, err = bus.ChannelGroup()
+	if err == nil {
+		t.Error("expected an error for an invalid bus")
+	}
+
+	var vca VCA
+	if vca.IsValid() {
+		t.Error("expected an invalid VCA")
+	}
+
+	err = vca.SetVolume(0.5)
+	if err == nil {
+		t.Error("expected an error for an invalid VCA")
+	}
+
+	<-done
+}
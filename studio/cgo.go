@@ -0,0 +1,11 @@
+package studio
+
+/*
+#cgo pkg-config: --define-variable=prefix=.. fmodstudio
+*/
+import "C"
+import "runtime"
+
+func init() {
+	runtime.LockOSThread()
+}
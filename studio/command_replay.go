@@ -0,0 +1,266 @@
+package studio
+
+/*
+#include <stdlib.h>
+#include <fmod_studio.h>
+*/
+import "C"
+import (
+	"errors"
+	"sort"
+	"unsafe"
+)
+
+// Returned by "CommandReplay.StepFrame" when the current command belongs to the last frame of the replay.
+var ErrLastFrame = errors.New("The current command belongs to the last frame of the replay.")
+
+// A loaded command capture, created with "System.LoadCommandReplay".
+// Commands are executed as the Studio System is updated, once "CommandReplay.Start" has been called.
+type CommandReplay struct {
+	cptr *C.FMOD_STUDIO_COMMANDREPLAY
+
+	// Index of the first command of every frame, see "CommandReplay.StepFrame".
+	frames []int
+}
+
+// Describes a command replay command, see "CommandReplay.CommandInfo".
+type CommandInfo struct {
+	// The full name of the API function for this command.
+	CommandName string
+
+	// For commands that operate on an instance, this is the command that created the instance.
+	ParentCommandIndex int
+
+	// The frame the command belongs to.
+	FrameNumber int
+
+	// The playback time at which this command will be executed, in seconds.
+	FrameTime float64
+
+	// The type of object that this command uses as an input.
+	InstanceType InstanceType
+
+	// The type of object that this command outputs, if any.
+	OutputType InstanceType
+
+	// The original handle value of the instance. This will no longer correspond to any actual object in playback.
+	InstanceHandle uint32
+
+	// The original handle value of the command output. This will no longer correspond to any actual object in playback.
+	OutputHandle uint32
+}
+
+func (c *CommandInfo) fromC(cc C.FMOD_STUDIO_COMMAND_INFO) {
+	c.CommandName = C.GoString(cc.commandname)
+	c.ParentCommandIndex = int(cc.parentcommandindex)
+	c.FrameNumber = int(cc.framenumber)
+	c.FrameTime = float64(cc.frametime)
+	c.InstanceType = InstanceType(cc.instancetype)
+	c.OutputType = InstanceType(cc.outputtype)
+	c.InstanceHandle = uint32(cc.instancehandle)
+	c.OutputHandle = uint32(cc.outputhandle)
+}
+
+/*
+   'CommandReplay' API
+*/
+
+// Checks that the CommandReplay reference is valid.
+func (r *CommandReplay) IsValid() bool {
+	return setBool(C.FMOD_Studio_CommandReplay_IsValid(r.cptr))
+}
+
+// Releases the command replay.
+func (r *CommandReplay) Release() error {
+	res := C.FMOD_Studio_CommandReplay_Release(r.cptr)
+	return errs[res]
+}
+
+// Sets a path substitution that will be used when loading banks with this replay.
+// Banks are loaded from path + the original bank filename, so captures from another machine can be replayed.
+func (r *CommandReplay) SetBankPath(path string) error {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	res := C.FMOD_Studio_CommandReplay_SetBankPath(r.cptr, cpath)
+	return errs[res]
+}
+
+/*
+   Playback control.
+*/
+
+// Begins playback.
+// If the replay is already running then calling this function will restart replay from the start.
+func (r *CommandReplay) Start() error {
+	res := C.FMOD_Studio_CommandReplay_Start(r.cptr)
+	return errs[res]
+}
+
+// Stops playback.
+// If "COMMANDREPLAY_SKIP_CLEANUP" was not passed to "System.LoadCommandReplay", the resources created by the replay are released.
+func (r *CommandReplay) Stop() error {
+	res := C.FMOD_Studio_CommandReplay_Stop(r.cptr)
+	return errs[res]
+}
+
+// Retrieves the playback state.
+func (r *CommandReplay) PlaybackState() (PlaybackState, error) {
+	var state C.FMOD_STUDIO_PLAYBACK_STATE
+	res := C.FMOD_Studio_CommandReplay_GetPlaybackState(r.cptr, &state)
+	return PlaybackState(state), errs[res]
+}
+
+// Sets the paused state.
+func (r *CommandReplay) SetPaused(paused bool) error {
+	res := C.FMOD_Studio_CommandReplay_SetPaused(r.cptr, getBool(paused))
+	return errs[res]
+}
+
+// Retrieves the paused state.
+func (r *CommandReplay) IsPaused() (bool, error) {
+	var paused C.FMOD_BOOL
+	res := C.FMOD_Studio_CommandReplay_GetPaused(r.cptr, &paused)
+	return setBool(paused), errs[res]
+}
+
+// Seeks the playback position to a time.
+//
+// time: The time to seek to, in seconds.
+//
+// This function moves the playback position to the first command at or after time.
+// If no command exists at or after time then an error is returned.
+func (r *CommandReplay) SeekToTime(time float64) error {
+	res := C.FMOD_Studio_CommandReplay_SeekToTime(r.cptr, C.float(time))
+	return errs[res]
+}
+
+// Seeks the playback position to a command.
+//
+// index: The index of the command to seek to.
+//
+// Commands before index are executed immediately so that the state matches the original session.
+func (r *CommandReplay) SeekToCommand(index int) error {
+	res := C.FMOD_Studio_CommandReplay_SeekToCommand(r.cptr, C.int(index))
+	return errs[res]
+}
+
+// Retrieves the progress through the command replay.
+// Returns the index of the command that is currently executing and the total time since playback started, in seconds.
+func (r *CommandReplay) CurrentCommand() (int, float64, error) {
+	var index C.int
+	var time C.float
+	res := C.FMOD_Studio_CommandReplay_GetCurrentCommand(r.cptr, &index, &time)
+	return int(index), float64(time), errs[res]
+}
+
+// Advances playback to the first command of the next frame.
+// Returns "ErrLastFrame" if the current command belongs to the last frame.
+//
+// Pause the replay with "CommandReplay.SetPaused" to step through it frame by frame,
+// and call "System.Update" after each step so that the commands are processed.
+func (r *CommandReplay) StepFrame() error {
+	if r.frames == nil {
+		frames, err := r.frameStarts()
+		if err != nil {
+			return err
+		}
+		r.frames = frames
+	}
+	current, _, err := r.CurrentCommand()
+	if err != nil {
+		return err
+	}
+	if current < 0 {
+		current = 0
+	}
+	// The first frame starting after the current command.
+	next := sort.SearchInts(r.frames, current+1)
+	if next == len(r.frames) {
+		return ErrLastFrame
+	}
+	return r.SeekToCommand(r.frames[next])
+}
+
+// frameStarts returns the index of the first command of every frame, in order.
+// A capture does not change once loaded, so it only needs to be read once.
+func (r *CommandReplay) frameStarts() ([]int, error) {
+	count, err := r.CommandCount()
+	if err != nil {
+		return nil, err
+	}
+	frames := []int{}
+	last := -1
+	for i := 0; i < count; i++ {
+		info, err := r.CommandInfo(i)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 || info.FrameNumber != last {
+			frames = append(frames, i)
+			last = info.FrameNumber
+		}
+	}
+	return frames, nil
+}
+
+/*
+   Information.
+*/
+
+// Retrieves the total playback time, in seconds.
+func (r *CommandReplay) Length() (float64, error) {
+	var length C.float
+	res := C.FMOD_Studio_CommandReplay_GetLength(r.cptr, &length)
+	return float64(length), errs[res]
+}
+
+// Retrieves the number of commands in the replay.
+func (r *CommandReplay) CommandCount() (int, error) {
+	var count C.int
+	res := C.FMOD_Studio_CommandReplay_GetCommandCount(r.cptr, &count)
+	return int(count), errs[res]
+}
+
+// Retrieves command information.
+//
+// index: The index of the command, from 0 to "CommandReplay.CommandCount" - 1.
+func (r *CommandReplay) CommandInfo(index int) (CommandInfo, error) {
+	var cinfo C.FMOD_STUDIO_COMMAND_INFO
+	var info CommandInfo
+	res := C.FMOD_Studio_CommandReplay_GetCommandInfo(r.cptr, C.int(index), &cinfo)
+	if res == C.FMOD_OK {
+		info.fromC(cinfo)
+	}
+	return info, errs[res]
+}
+
+// Retrieves the information of every command in the replay.
+func (r *CommandReplay) Commands() ([]CommandInfo, error) {
+	count, err := r.CommandCount()
+	if err != nil {
+		return nil, err
+	}
+	commands := make([]CommandInfo, count)
+	for i := range commands {
+		commands[i], err = r.CommandInfo(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return commands, nil
+}
+
+// Retrieves a string representation of a command, including its parameters.
+func (r *CommandReplay) CommandString(index int) (string, error) {
+	// The function truncates instead of reporting the required size, so use a buffer large enough for any command.
+	buf := make([]byte, 1024)
+	res := C.FMOD_Studio_CommandReplay_GetCommandString(r.cptr, C.int(index), (*C.char)(unsafe.Pointer(&buf[0])), C.int(len(buf)))
+	return C.GoString((*C.char)(unsafe.Pointer(&buf[0]))), errs[res]
+}
+
+// Retrieves the index of the command that starts at the given time, in seconds.
+func (r *CommandReplay) CommandAtTime(time float64) (int, error) {
+	var index C.int
+	res := C.FMOD_Studio_CommandReplay_GetCommandAtTime(r.cptr, C.float(time), &index)
+	return int(index), errs[res]
+}
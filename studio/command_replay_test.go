@@ -0,0 +1,128 @@
+package studio
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/theaidem/fmod/lowlevel"
+)
+
+func TestCommandReplay(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	capture := filepath.Join(t.TempDir(), "capture.cmd.raw")
+	err = system.StartCommandCapture(capture, COMMANDCAPTURE_NORMAL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A few frames of commands, which do not need any bank.
+	err = system.SetNumListeners(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		err = system.Update()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		attributes := Attributes3D{Position: lowlevel.Vector{X: float32(i)}, Forward: lowlevel.Vector{Z: 1}, Up: lowlevel.Vector{Y: 1}}
+		err = system.SetListenerAttributes(1, attributes)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = system.Update()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.StopCommandCapture()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := system.LoadCommandReplay(capture, COMMANDREPLAY_NORMAL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commands, err := replay.Commands()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frames := map[int]bool{}
+	for _, command := range commands {
+		frames[command.FrameNumber] = true
+	}
+
+	if len(frames) < 2 {
+		t.Fatalf("expected commands in several frames but got %+v", commands)
+	}
+
+	err = replay.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = replay.SetPaused(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.Update()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := 0
+	last := -1
+	for {
+		err = replay.StepFrame()
+		if err == ErrLastFrame {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		steps++
+
+		err = system.Update()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		index, _, err := replay.CurrentCommand()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if index <= last || index >= len(commands) {
+			t.Fatalf("expected the replay to move forward from command %d but got %d", last, index)
+		}
+		last = index
+	}
+
+	if steps != len(frames)-1 {
+		t.Errorf("expected %d steps over %d frames but got %d", len(frames)-1, len(frames), steps)
+	}
+
+	err = replay.Stop()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = replay.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
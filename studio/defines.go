@@ -0,0 +1,223 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+
+// Studio System initialization flags.
+// Use them with "System.Initialize" in the studioflags parameter to change various behaviour.
+type InitFlags C.FMOD_STUDIO_INITFLAGS
+
+const (
+	// Initialize normally.
+	INIT_NORMAL InitFlags = C.FMOD_STUDIO_INIT_NORMAL
+
+	// Enable live update.
+	INIT_LIVEUPDATE = C.FMOD_STUDIO_INIT_LIVEUPDATE
+
+	// Load banks even if they reference plugins that have not been loaded.
+	INIT_ALLOW_MISSING_PLUGINS = C.FMOD_STUDIO_INIT_ALLOW_MISSING_PLUGINS
+
+	// Disable asynchronous processing and perform all processing on the calling thread instead.
+	INIT_SYNCHRONOUS_UPDATE = C.FMOD_STUDIO_INIT_SYNCHRONOUS_UPDATE
+
+	// Defer timeline callbacks until the main update.
+	INIT_DEFERRED_CALLBACKS = C.FMOD_STUDIO_INIT_DEFERRED_CALLBACKS
+)
+
+// Flags passed into Studio "System.LoadBankFile" and "System.LoadBankMemory" to control bank load behaviour.
+type LoadBankFlags C.FMOD_STUDIO_LOAD_BANK_FLAGS
+
+const (
+	// Standard behaviour.
+	LOAD_BANK_NORMAL LoadBankFlags = C.FMOD_STUDIO_LOAD_BANK_NORMAL
+
+	// Force samples to decompress into memory when they are loaded, rather than staying compressed.
+	LOAD_BANK_DECOMPRESS_SAMPLES = C.FMOD_STUDIO_LOAD_BANK_DECOMPRESS_SAMPLES
+
+	// Bank loading occurs asynchronously rather than occurring immediately.
+	// Use "Bank.LoadingState" or "Bank.WaitLoaded" to find out when the bank is ready.
+	LOAD_BANK_NONBLOCKING = C.FMOD_STUDIO_LOAD_BANK_NONBLOCKING
+)
+
+// Loading state of various objects, returned by "Bank.LoadingState" and "Bank.SampleLoadingState".
+type LoadingState C.FMOD_STUDIO_LOADING_STATE
+
+const (
+	// Currently unloading.
+	LOADING_STATE_UNLOADING LoadingState = C.FMOD_STUDIO_LOADING_STATE_UNLOADING
+
+	// Not loaded.
+	LOADING_STATE_UNLOADED = C.FMOD_STUDIO_LOADING_STATE_UNLOADED
+
+	// Loading in progress.
+	LOADING_STATE_LOADING = C.FMOD_STUDIO_LOADING_STATE_LOADING
+
+	// Loaded and ready to play.
+	LOADING_STATE_LOADED = C.FMOD_STUDIO_LOADING_STATE_LOADED
+
+	// Failed to load.
+	LOADING_STATE_ERROR = C.FMOD_STUDIO_LOADING_STATE_ERROR
+)
+
+// Controls how to stop playback of an event instance.
+type StopMode C.FMOD_STUDIO_STOP_MODE
+
+const (
+	// Allows AHDSR modulators to complete their release, and DSP effect tails to play out.
+	STOP_ALLOWFADEOUT StopMode = C.FMOD_STUDIO_STOP_ALLOWFADEOUT
+
+	// Stops the event instance immediately.
+	STOP_IMMEDIATE = C.FMOD_STUDIO_STOP_IMMEDIATE
+)
+
+// Playback state of an event instance.
+type PlaybackState C.FMOD_STUDIO_PLAYBACK_STATE
+
+const (
+	// Currently playing.
+	PLAYBACK_PLAYING PlaybackState = C.FMOD_STUDIO_PLAYBACK_PLAYING
+
+	// The timeline cursor is paused on a sustain point.
+	PLAYBACK_SUSTAINING = C.FMOD_STUDIO_PLAYBACK_SUSTAINING
+
+	// Not playing.
+	PLAYBACK_STOPPED = C.FMOD_STUDIO_PLAYBACK_STOPPED
+
+	// Start has been called but the instance is not fully started yet.
+	PLAYBACK_STARTING = C.FMOD_STUDIO_PLAYBACK_STARTING
+
+	// Stop has been called but the instance is not fully stopped yet.
+	PLAYBACK_STOPPING = C.FMOD_STUDIO_PLAYBACK_STOPPING
+)
+
+// Describes the class of a parameter.
+type ParameterType C.FMOD_STUDIO_PARAMETER_TYPE
+
+const (
+	// Controlled via the API using "EventInstance.SetParameterByName" or "EventInstance.SetParameterByID".
+	PARAMETER_GAME_CONTROLLED ParameterType = C.FMOD_STUDIO_PARAMETER_GAME_CONTROLLED
+
+	// Distance between the event and the listener.
+	PARAMETER_AUTOMATIC_DISTANCE = C.FMOD_STUDIO_PARAMETER_AUTOMATIC_DISTANCE
+
+	// Angle between the event's forward vector and the vector pointing from the event to the listener (0 to 180 degrees).
+	PARAMETER_AUTOMATIC_EVENT_CONE_ANGLE = C.FMOD_STUDIO_PARAMETER_AUTOMATIC_EVENT_CONE_ANGLE
+
+	// Horizontal angle between the event's forward vector and listener's forward vector (-180 to 180 degrees).
+	PARAMETER_AUTOMATIC_EVENT_ORIENTATION = C.FMOD_STUDIO_PARAMETER_AUTOMATIC_EVENT_ORIENTATION
+
+	// Horizontal angle between the listener's forward vector and the vector pointing from the listener to the event (-180 to 180 degrees).
+	PARAMETER_AUTOMATIC_DIRECTION = C.FMOD_STUDIO_PARAMETER_AUTOMATIC_DIRECTION
+
+	// Angle between the listener's XZ plane and the vector pointing from the listener to the event (-90 to 90 degrees).
+	PARAMETER_AUTOMATIC_ELEVATION = C.FMOD_STUDIO_PARAMETER_AUTOMATIC_ELEVATION
+
+	// Horizontal angle between the listener's forward vector and the global positive Z axis (-180 to 180 degrees).
+	PARAMETER_AUTOMATIC_LISTENER_ORIENTATION = C.FMOD_STUDIO_PARAMETER_AUTOMATIC_LISTENER_ORIENTATION
+)
+
+// These callback types are used with "EventInstance.SetCallback".
+type EventCallbackType C.FMOD_STUDIO_EVENT_CALLBACK_TYPE
+
+const (
+	// Called when an instance is fully created.
+	EVENT_CALLBACK_CREATED EventCallbackType = C.FMOD_STUDIO_EVENT_CALLBACK_CREATED
+
+	// Called when an instance is just about to be destroyed.
+	EVENT_CALLBACK_DESTROYED = C.FMOD_STUDIO_EVENT_CALLBACK_DESTROYED
+
+	// Called when an instance is preparing to start.
+	EVENT_CALLBACK_STARTING = C.FMOD_STUDIO_EVENT_CALLBACK_STARTING
+
+	// Called when an instance starts playing.
+	EVENT_CALLBACK_STARTED = C.FMOD_STUDIO_EVENT_CALLBACK_STARTED
+
+	// Called when an instance is restarted.
+	EVENT_CALLBACK_RESTARTED = C.FMOD_STUDIO_EVENT_CALLBACK_RESTARTED
+
+	// Called when an instance stops.
+	EVENT_CALLBACK_STOPPED = C.FMOD_STUDIO_EVENT_CALLBACK_STOPPED
+
+	// Called when an instance did not start, e.g. due to polyphony.
+	EVENT_CALLBACK_START_FAILED = C.FMOD_STUDIO_EVENT_CALLBACK_START_FAILED
+
+	// Called when a programmer sound needs to be created in order to play a programmer instrument.
+	EVENT_CALLBACK_CREATE_PROGRAMMER_SOUND = C.FMOD_STUDIO_EVENT_CALLBACK_CREATE_PROGRAMMER_SOUND
+
+	// Called when a programmer sound needs to be destroyed.
+	EVENT_CALLBACK_DESTROY_PROGRAMMER_SOUND = C.FMOD_STUDIO_EVENT_CALLBACK_DESTROY_PROGRAMMER_SOUND
+
+	// Called when the timeline passes a named marker.
+	EVENT_CALLBACK_TIMELINE_MARKER = C.FMOD_STUDIO_EVENT_CALLBACK_TIMELINE_MARKER
+
+	// Called when the timeline hits a beat in a tempo section.
+	EVENT_CALLBACK_TIMELINE_BEAT = C.FMOD_STUDIO_EVENT_CALLBACK_TIMELINE_BEAT
+
+	// Called when the event plays a sound.
+	EVENT_CALLBACK_SOUND_PLAYED = C.FMOD_STUDIO_EVENT_CALLBACK_SOUND_PLAYED
+
+	// Called when the event finishes playing a sound.
+	EVENT_CALLBACK_SOUND_STOPPED = C.FMOD_STUDIO_EVENT_CALLBACK_SOUND_STOPPED
+
+	// Pass this mask to "EventInstance.SetCallback" to receive all callback types.
+	EVENT_CALLBACK_ALL = C.FMOD_STUDIO_EVENT_CALLBACK_ALL
+)
+
+// Flags passed into "System.StartCommandCapture".
+type CommandCaptureFlags C.FMOD_STUDIO_COMMANDCAPTURE_FLAGS
+
+const (
+	// Standard behaviour.
+	COMMANDCAPTURE_NORMAL CommandCaptureFlags = C.FMOD_STUDIO_COMMANDCAPTURE_NORMAL
+
+	// Call file flush on every command.
+	COMMANDCAPTURE_FILEFLUSH = C.FMOD_STUDIO_COMMANDCAPTURE_FILEFLUSH
+
+	// Normally the initial state of banks and instances is captured, unless this flag is set.
+	COMMANDCAPTURE_SKIP_INITIAL_STATE = C.FMOD_STUDIO_COMMANDCAPTURE_SKIP_INITIAL_STATE
+)
+
+// Flags passed into "System.LoadCommandReplay".
+type CommandReplayFlags C.FMOD_STUDIO_COMMANDREPLAY_FLAGS
+
+const (
+	// Standard behaviour.
+	COMMANDREPLAY_NORMAL CommandReplayFlags = C.FMOD_STUDIO_COMMANDREPLAY_NORMAL
+
+	// Normally the playback will release any created resources when it stops, unless this flag is set.
+	COMMANDREPLAY_SKIP_CLEANUP = C.FMOD_STUDIO_COMMANDREPLAY_SKIP_CLEANUP
+)
+
+// Identifies the type of a Studio API object, see "CommandInfo".
+type InstanceType C.FMOD_STUDIO_INSTANCETYPE
+
+const (
+	// No instance.
+	INSTANCETYPE_NONE InstanceType = C.FMOD_STUDIO_INSTANCETYPE_NONE
+
+	// The Studio "System".
+	INSTANCETYPE_SYSTEM = C.FMOD_STUDIO_INSTANCETYPE_SYSTEM
+
+	// An "EventDescription".
+	INSTANCETYPE_EVENTDESCRIPTION = C.FMOD_STUDIO_INSTANCETYPE_EVENTDESCRIPTION
+
+	// An "EventInstance".
+	INSTANCETYPE_EVENTINSTANCE = C.FMOD_STUDIO_INSTANCETYPE_EVENTINSTANCE
+
+	// A parameter instance.
+	INSTANCETYPE_PARAMETERINSTANCE = C.FMOD_STUDIO_INSTANCETYPE_PARAMETERINSTANCE
+
+	// A "Bus".
+	INSTANCETYPE_BUS = C.FMOD_STUDIO_INSTANCETYPE_BUS
+
+	// A "VCA".
+	INSTANCETYPE_VCA = C.FMOD_STUDIO_INSTANCETYPE_VCA
+
+	// A "Bank".
+	INSTANCETYPE_BANK = C.FMOD_STUDIO_INSTANCETYPE_BANK
+
+	// A "CommandReplay".
+	INSTANCETYPE_COMMANDREPLAY = C.FMOD_STUDIO_INSTANCETYPE_COMMANDREPLAY
+)
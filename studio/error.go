@@ -0,0 +1,22 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+import "github.com/theaidem/fmod/lowlevel"
+
+var errs map[C.FMOD_RESULT]error
+
+// Returned when an event, bus or VCA can not be found by path or ID, for example by "System.Bus" or "System.VCAByID".
+// It is the same value as the lowlevel error for FMOD_ERR_EVENT_NOTFOUND.
+var ErrNotFound error
+
+func init() {
+	// Share the error values with the lowlevel package, so callers can compare errors returned by both APIs.
+	errs = make(map[C.FMOD_RESULT]error)
+	for res, err := range lowlevel.ResultErrors() {
+		errs[C.FMOD_RESULT(res)] = err
+	}
+	ErrNotFound = errs[C.FMOD_ERR_EVENT_NOTFOUND]
+}
@@ -0,0 +1,346 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/theaidem/fmod/lowlevel"
+)
+
+// Describes a named marker on an event's timeline, reported with "EVENT_CALLBACK_TIMELINE_MARKER".
+type TimelineMarker struct {
+	// Name of the marker.
+	Name string
+
+	// Position of the marker on the timeline, in milliseconds.
+	Position int
+}
+
+// Describes a beat on an event's timeline, reported with "EVENT_CALLBACK_TIMELINE_BEAT".
+type TimelineBeat struct {
+	// Bar number (starting from 1).
+	Bar int
+
+	// Beat number within the bar (starting from 1).
+	Beat int
+
+	// Position of the beat on the timeline, in milliseconds.
+	Position int
+
+	// Current tempo, in beats per minute.
+	Tempo float64
+
+	// Current time signature upper number (beats per bar).
+	TimeSignatureUpper int
+
+	// Current time signature lower number (beat unit).
+	TimeSignatureLower int
+}
+
+// Describes a programmer sound, reported with "EVENT_CALLBACK_CREATE_PROGRAMMER_SOUND" and "EVENT_CALLBACK_DESTROY_PROGRAMMER_SOUND".
+type ProgrammerSound struct {
+	// Name of the programmer instrument, as set in FMOD Studio.
+	Name string
+
+	// The sound used by the instrument, if any.
+	// Sounds supplied by a "ProgrammerSoundTable" have already been released when "EVENT_CALLBACK_DESTROY_PROGRAMMER_SOUND" is delivered.
+	Sound *lowlevel.Sound
+
+	// Subsound index of the sound, or -1 if the sound itself is played.
+	SubsoundIndex int
+}
+
+// An event instance callback, delivered on the channel registered with "EventInstance.SetCallback".
+// Only the field matching Type is set.
+type EventCallback struct {
+	// The callback type.
+	Type EventCallbackType
+
+	// The event instance the callback was raised for.
+	Instance *EventInstance
+
+	// Set for "EVENT_CALLBACK_TIMELINE_MARKER".
+	Marker *TimelineMarker
+
+	// Set for "EVENT_CALLBACK_TIMELINE_BEAT".
+	Beat *TimelineBeat
+
+	// Set for "EVENT_CALLBACK_CREATE_PROGRAMMER_SOUND" and "EVENT_CALLBACK_DESTROY_PROGRAMMER_SOUND".
+	ProgrammerSound *ProgrammerSound
+
+	// Set for "EVENT_CALLBACK_SOUND_PLAYED" and "EVENT_CALLBACK_SOUND_STOPPED".
+	Sound *lowlevel.Sound
+}
+
+type queuedEventCallback struct {
+	ev      EventCallback
+	deliver bool
+}
+
+// eventCallbackQueue buffers callbacks raised on FMOD's threads and forwards them to the user's channel from its own goroutine,
+// so FMOD is never blocked by a slow receiver and events are never dropped.
+type eventCallbackQueue struct {
+	mask    EventCallbackType
+	out     chan<- EventCallback
+	mu      sync.Mutex
+	pending []queuedEventCallback
+	wake    chan struct{}
+	done    chan struct{}
+	// Closed once the goroutine forwarding the callbacks has exited.
+	exited chan struct{}
+}
+
+func newEventCallbackQueue(mask EventCallbackType, out chan<- EventCallback) *eventCallbackQueue {
+	q := &eventCallbackQueue{
+		mask:   mask,
+		out:    out,
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *eventCallbackQueue) push(ev EventCallback) {
+	q.mu.Lock()
+	q.pending = append(q.pending, queuedEventCallback{ev: ev, deliver: q.mask&ev.Type != 0})
+	q.mu.Unlock()
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *eventCallbackQueue) stop() {
+	close(q.done)
+}
+
+func (q *eventCallbackQueue) run() {
+	defer close(q.exited)
+	for {
+		select {
+		case <-q.wake:
+		case <-q.done:
+			return
+		}
+		q.mu.Lock()
+		batch := q.pending
+		q.pending = nil
+		q.mu.Unlock()
+		for _, item := range batch {
+			if item.deliver {
+				select {
+				case q.out <- item.ev:
+				case <-q.done:
+					return
+				}
+			}
+			// The instance is gone, nothing else can be raised for it.
+			if item.ev.Type == EVENT_CALLBACK_DESTROYED {
+				return
+			}
+		}
+	}
+}
+
+// eventCallbackEntry holds everything registered for one event instance.
+type eventCallbackEntry struct {
+	queue *eventCallbackQueue
+	table *ProgrammerSoundTable
+
+	// Sounds handed to programmer instruments, kept referenced until FMOD destroys the instrument.
+	owned map[uintptr]*lowlevel.Sound
+}
+
+func (e *eventCallbackEntry) mask() EventCallbackType {
+	var mask EventCallbackType
+	if e.queue != nil {
+		mask |= e.queue.mask
+	}
+	// Instruments still holding a sound must report their destruction, even once the table has been removed.
+	if e.table != nil || len(e.owned) > 0 {
+		mask |= EVENT_CALLBACK_CREATE_PROGRAMMER_SOUND | EVENT_CALLBACK_DESTROY_PROGRAMMER_SOUND
+	}
+	return mask
+}
+
+// Registered callbacks, keyed by event instance pointer.
+var eventCallbacks = struct {
+	sync.Mutex
+	m map[uintptr]*eventCallbackEntry
+}{m: make(map[uintptr]*eventCallbackEntry)}
+
+// updateEventCallback applies update to the registration of event, then installs or removes the FMOD callback to match it.
+func updateEventCallback(event *C.FMOD_STUDIO_EVENTINSTANCE, update func(entry *eventCallbackEntry)) error {
+	key := uintptr(unsafe.Pointer(event))
+	eventCallbacks.Lock()
+	entry, ok := eventCallbacks.m[key]
+	if !ok {
+		entry = &eventCallbackEntry{owned: make(map[uintptr]*lowlevel.Sound)}
+	}
+	update(entry)
+	mask := entry.mask()
+	if mask == 0 {
+		delete(eventCallbacks.m, key)
+	} else {
+		eventCallbacks.m[key] = entry
+	}
+	eventCallbacks.Unlock()
+
+	// FMOD is called without holding the registry lock, as callbacks may be running on FMOD's threads.
+	var res C.FMOD_RESULT
+	if mask == 0 {
+		res = C.FMOD_Studio_EventInstance_SetCallback(event, nil, 0)
+	} else {
+		// DESTROYED is always requested so the registration can be cleaned up.
+		res = C.FMOD_Studio_EventInstance_SetCallback(event, (C.FMOD_STUDIO_EVENT_CALLBACK)(unsafe.Pointer(C.goStudioEventCallback)), C.FMOD_STUDIO_EVENT_CALLBACK_TYPE(mask|EVENT_CALLBACK_DESTROYED))
+	}
+	if res != C.FMOD_OK && !ok {
+		// The instance is invalid, so nothing will ever be raised for it.
+		dropEventCallback(key, entry)
+	}
+	return errs[res]
+}
+
+// dropEventCallback removes entry from the registry if it is still registered for key, and stops its queue.
+func dropEventCallback(key uintptr, entry *eventCallbackEntry) {
+	eventCallbacks.Lock()
+	if eventCallbacks.m[key] == entry {
+		delete(eventCallbacks.m, key)
+	}
+	queue := entry.queue
+	entry.queue = nil
+	eventCallbacks.Unlock()
+	if queue != nil {
+		queue.stop()
+	}
+}
+
+// lookupEventCallback returns the registration of an event instance, with its queue and programmer sound table.
+// On "EVENT_CALLBACK_DESTROYED" the registration is removed, and the sounds still held by its instruments are released.
+func lookupEventCallback(key uintptr, typ EventCallbackType) (*eventCallbackEntry, *eventCallbackQueue, *ProgrammerSoundTable) {
+	eventCallbacks.Lock()
+	entry, ok := eventCallbacks.m[key]
+	if !ok {
+		eventCallbacks.Unlock()
+		return nil, nil, nil
+	}
+	queue, table := entry.queue, entry.table
+	var leftover []*lowlevel.Sound
+	if typ == EVENT_CALLBACK_DESTROYED {
+		// The instance pointer may be reused by FMOD after this point.
+		delete(eventCallbacks.m, key)
+		for _, sound := range entry.owned {
+			leftover = append(leftover, sound)
+		}
+		entry.owned = nil
+	}
+	eventCallbacks.Unlock()
+	for _, sound := range leftover {
+		sound.Release()
+	}
+	return entry, queue, table
+}
+
+//export goStudioEventCallback
+func goStudioEventCallback(typ C.FMOD_STUDIO_EVENT_CALLBACK_TYPE, event *C.FMOD_STUDIO_EVENTINSTANCE, parameters unsafe.Pointer) C.FMOD_RESULT {
+	key := uintptr(unsafe.Pointer(event))
+	entry, queue, table := lookupEventCallback(key, EventCallbackType(typ))
+	if entry == nil {
+		return C.FMOD_OK
+	}
+
+	// Parameters are only valid for the duration of the callback, so everything is copied into Go values.
+	ev := EventCallback{Type: EventCallbackType(typ), Instance: &EventInstance{cptr: event}}
+	switch ev.Type {
+	case EVENT_CALLBACK_TIMELINE_MARKER:
+		props := (*C.FMOD_STUDIO_TIMELINE_MARKER_PROPERTIES)(parameters)
+		ev.Marker = &TimelineMarker{
+			Name:     C.GoString(props.name),
+			Position: int(props.position),
+		}
+	case EVENT_CALLBACK_TIMELINE_BEAT:
+		props := (*C.FMOD_STUDIO_TIMELINE_BEAT_PROPERTIES)(parameters)
+		ev.Beat = &TimelineBeat{
+			Bar:                int(props.bar),
+			Beat:               int(props.beat),
+			Position:           int(props.position),
+			Tempo:              float64(props.tempo),
+			TimeSignatureUpper: int(props.timesignatureupper),
+			TimeSignatureLower: int(props.timesignaturelower),
+		}
+	case EVENT_CALLBACK_CREATE_PROGRAMMER_SOUND:
+		props := (*C.FMOD_STUDIO_PROGRAMMER_SOUND_PROPERTIES)(parameters)
+		ev.ProgrammerSound = &ProgrammerSound{
+			Name:          C.GoString(props.name),
+			SubsoundIndex: int(props.subsoundIndex),
+		}
+		if table != nil {
+			createProgrammerSound(key, entry, table, props, ev.ProgrammerSound)
+		}
+	case EVENT_CALLBACK_DESTROY_PROGRAMMER_SOUND:
+		props := (*C.FMOD_STUDIO_PROGRAMMER_SOUND_PROPERTIES)(parameters)
+		ev.ProgrammerSound = &ProgrammerSound{
+			Name:          C.GoString(props.name),
+			SubsoundIndex: int(props.subsoundIndex),
+		}
+		if props.sound != nil {
+			ev.ProgrammerSound.Sound = lowlevel.SoundFromPointer(unsafe.Pointer(props.sound))
+			releaseProgrammerSound(entry, uintptr(unsafe.Pointer(props.sound)))
+		}
+	case EVENT_CALLBACK_SOUND_PLAYED, EVENT_CALLBACK_SOUND_STOPPED:
+		ev.Sound = lowlevel.SoundFromPointer(parameters)
+	}
+	if queue != nil {
+		queue.push(ev)
+	}
+	return C.FMOD_OK
+}
+
+// createProgrammerSound resolves the sound of a programmer instrument from table and hands it to FMOD.
+// If the instrument has no sound in the table, no sound is set and the instrument stays silent.
+func createProgrammerSound(key uintptr, entry *eventCallbackEntry, table *ProgrammerSoundTable, props *C.FMOD_STUDIO_PROGRAMMER_SOUND_PROPERTIES, ps *ProgrammerSound) {
+	sound, subsoundIndex, err := table.resolve(ps.Name)
+	if err != nil {
+		return
+	}
+	if !ownProgrammerSound(key, entry, sound) {
+		return
+	}
+	props.sound = (*C.FMOD_SOUND)(sound.Pointer())
+	props.subsoundIndex = C.int(subsoundIndex)
+	ps.Sound = sound
+	ps.SubsoundIndex = subsoundIndex
+}
+
+// ownProgrammerSound keeps sound referenced until its instrument is destroyed.
+// If the instance has been destroyed meanwhile, nothing would ever release the sound, so it is released right away.
+func ownProgrammerSound(key uintptr, entry *eventCallbackEntry, sound *lowlevel.Sound) bool {
+	eventCallbacks.Lock()
+	registered := eventCallbacks.m[key] == entry && entry.owned != nil
+	if registered {
+		entry.owned[uintptr(sound.Pointer())] = sound
+	}
+	eventCallbacks.Unlock()
+	if !registered {
+		sound.Release()
+	}
+	return registered
+}
+
+// releaseProgrammerSound releases a sound resolved from a "ProgrammerSoundTable" once FMOD is done with it.
+// Sounds set by other means are left alone.
+func releaseProgrammerSound(entry *eventCallbackEntry, key uintptr) {
+	eventCallbacks.Lock()
+	sound, ok := entry.owned[key]
+	delete(entry.owned, key)
+	eventCallbacks.Unlock()
+	if ok {
+		sound.Release()
+	}
+}
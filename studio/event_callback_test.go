@@ -0,0 +1,124 @@
+package studio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventCallbackQueue(t *testing.T) {
+	events := make(chan EventCallback)
+	queue := newEventCallbackQueue(EVENT_CALLBACK_TIMELINE_BEAT|EVENT_CALLBACK_DESTROYED, events)
+
+	// Pushing never blocks FMOD's thread, even while nobody receives.
+	for bar := 1; bar <= 100; bar++ {
+		queue.push(EventCallback{Type: EVENT_CALLBACK_STARTED})
+		queue.push(EventCallback{Type: EVENT_CALLBACK_TIMELINE_BEAT, Beat: &TimelineBeat{Bar: bar}})
+	}
+	queue.push(EventCallback{Type: EVENT_CALLBACK_DESTROYED})
+	queue.push(EventCallback{Type: EVENT_CALLBACK_TIMELINE_BEAT, Beat: &TimelineBeat{Bar: 101}})
+
+	// Only the masked callbacks are delivered, in order.
+	for bar := 1; bar <= 100; bar++ {
+		ev := <-events
+		if ev.Type != EVENT_CALLBACK_TIMELINE_BEAT || ev.Beat.Bar != bar {
+			t.Fatalf("expected the beat of bar %d but got %+v", bar, ev)
+		}
+	}
+
+	ev := <-events
+	if ev.Type != EVENT_CALLBACK_DESTROYED {
+		t.Fatal("expected EVENT_CALLBACK_DESTROYED but got", ev.Type)
+	}
+
+	// Nothing is raised for a destroyed instance, so the goroutine is done.
+	select {
+	case <-queue.exited:
+	case <-time.After(time.Second):
+		t.Fatal("expected the queue to exit once the instance is destroyed")
+	}
+
+	select {
+	case ev := <-events:
+		t.Error("expected no callback after EVENT_CALLBACK_DESTROYED but got", ev.Type)
+	default:
+	}
+}
+
+func TestEventCallbackQueueStop(t *testing.T) {
+	events := make(chan EventCallback)
+	queue := newEventCallbackQueue(EVENT_CALLBACK_ALL, events)
+
+	// Stopping does not wait for a receiver.
+	queue.push(EventCallback{Type: EVENT_CALLBACK_STARTED})
+	queue.stop()
+	select {
+	case <-queue.exited:
+	case <-time.After(time.Second):
+		t.Fatal("expected the queue to exit once stopped")
+	}
+}
+
+func TestEventCallbackRegistry(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing stays registered for an invalid instance, and its queue is stopped.
+	var instance EventInstance
+	err = instance.SetCallback(EVENT_CALLBACK_ALL, make(chan EventCallback))
+	if err == nil {
+		t.Error("expected an error for an invalid instance")
+	}
+
+	eventCallbacks.Lock()
+	_, ok := eventCallbacks.m[0]
+	eventCallbacks.Unlock()
+	if ok {
+		t.Error("expected no registration for an invalid instance")
+	}
+
+	// A fake instance key, registered the way updateEventCallback does.
+	const key = 1
+	events := make(chan EventCallback, 1)
+	entry := &eventCallbackEntry{queue: newEventCallbackQueue(EVENT_CALLBACK_STARTED, events)}
+	if entry.mask() != EVENT_CALLBACK_STARTED {
+		t.Errorf("expected mask %x but got %x", EVENT_CALLBACK_STARTED, entry.mask())
+	}
+
+	eventCallbacks.Lock()
+	eventCallbacks.m[key] = entry
+	eventCallbacks.Unlock()
+
+	got, queue, _ := lookupEventCallback(key, EVENT_CALLBACK_STARTED)
+	if got != entry || queue != entry.queue {
+		t.Error("expected the registration of the instance")
+	}
+
+	// Destruction removes the registration, the queue exits once it has delivered what was raised before.
+	got, queue, _ = lookupEventCallback(key, EVENT_CALLBACK_DESTROYED)
+	if got != entry {
+		t.Error("expected the registration of the destroyed instance")
+	}
+	queue.push(EventCallback{Type: EVENT_CALLBACK_DESTROYED})
+
+	eventCallbacks.Lock()
+	_, ok = eventCallbacks.m[key]
+	eventCallbacks.Unlock()
+	if ok {
+		t.Error("expected the registration to be removed when the instance is destroyed")
+	}
+
+	got, _, _ = lookupEventCallback(key, EVENT_CALLBACK_STARTED)
+	if got != nil {
+		t.Error("expected no registration after the instance is destroyed")
+	}
+
+	select {
+	case <-queue.exited:
+	case <-time.After(time.Second):
+		t.Fatal("expected the queue to exit once the instance is destroyed")
+	}
+
+	<-done
+}
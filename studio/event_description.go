@@ -0,0 +1,132 @@
+package studio
+
+/*
+#include <stdlib.h>
+#include <fmod_studio.h>
+*/
+import "C"
+import "unsafe"
+
+// The description for an FMOD Studio Event.
+// Event descriptions belong to banks and so it is only valid while the bank that contains it is loaded.
+// Use "EventDescription.CreateInstance" to create an instance that can be played.
+type EventDescription struct {
+	cptr *C.FMOD_STUDIO_EVENTDESCRIPTION
+}
+
+/*
+   'EventDescription' API
+*/
+
+// Checks that the EventDescription reference is valid.
+func (e *EventDescription) IsValid() bool {
+	return setBool(C.FMOD_Studio_EventDescription_IsValid(e.cptr))
+}
+
+// Retrieves the GUID of the event.
+func (e *EventDescription) ID() (GUID, error) {
+	var id C.FMOD_GUID
+	res := C.FMOD_Studio_EventDescription_GetID(e.cptr, &id)
+	return guidFromC(id), errs[res]
+}
+
+// Retrieves the path of the event, for example "event:/UI/Cancel".
+// The strings bank must be loaded for this function to succeed.
+func (e *EventDescription) Path() (string, error) {
+	return getString(func(buf *C.char, size C.int, retrieved *C.int) C.FMOD_RESULT {
+		return C.FMOD_Studio_EventDescription_GetPath(e.cptr, buf, size, retrieved)
+	})
+}
+
+// Retrieves the length of the timeline, in milliseconds.
+// A timeline's length is the largest of any logic markers, transition leadouts and the end of any trigger boxes on the timeline.
+func (e *EventDescription) Length() (int, error) {
+	var length C.int
+	res := C.FMOD_Studio_EventDescription_GetLength(e.cptr, &length)
+	return int(length), errs[res]
+}
+
+// Retrieves whether the event is a oneshot.
+// An event is considered to be a oneshot if it is guaranteed to terminate without intervention in bounded time after being started.
+func (e *EventDescription) IsOneshot() (bool, error) {
+	var oneshot C.FMOD_BOOL
+	res := C.FMOD_Studio_EventDescription_IsOneshot(e.cptr, &oneshot)
+	return setBool(oneshot), errs[res]
+}
+
+/*
+   Parameters.
+*/
+
+// Retrieves the number of parameters in the event.
+func (e *EventDescription) ParameterCount() (int, error) {
+	var count C.int
+	res := C.FMOD_Studio_EventDescription_GetParameterCount(e.cptr, &count)
+	return int(count), errs[res]
+}
+
+// Retrieves an event parameter description by index.
+//
+// index: Parameter index, from 0 to "EventDescription.ParameterCount" - 1.
+func (e *EventDescription) ParameterByIndex(index int) (ParameterDescription, error) {
+	var cparameter C.FMOD_STUDIO_PARAMETER_DESCRIPTION
+	var parameter ParameterDescription
+	res := C.FMOD_Studio_EventDescription_GetParameterByIndex(e.cptr, C.int(index), &cparameter)
+	parameter.fromC(cparameter)
+	return parameter, errs[res]
+}
+
+// Retrieves an event parameter description by name.
+//
+// name: Parameter name (case-insensitive).
+func (e *EventDescription) Parameter(name string) (ParameterDescription, error) {
+	var cparameter C.FMOD_STUDIO_PARAMETER_DESCRIPTION
+	var parameter ParameterDescription
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	res := C.FMOD_Studio_EventDescription_GetParameter(e.cptr, cname, &cparameter)
+	parameter.fromC(cparameter)
+	return parameter, errs[res]
+}
+
+// Retrieves the descriptions of all parameters in the event.
+func (e *EventDescription) Parameters() ([]ParameterDescription, error) {
+	count, err := e.ParameterCount()
+	if err != nil {
+		return nil, err
+	}
+	parameters := make([]ParameterDescription, count)
+	for i := range parameters {
+		parameters[i], err = e.ParameterByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return parameters, nil
+}
+
+/*
+   Instances.
+*/
+
+// Creates a playable instance of the event.
+// The instance is not started until "EventInstance.Start" is called, and must be released with "EventInstance.Release" once it is no longer needed.
+func (e *EventDescription) CreateInstance() (*EventInstance, error) {
+	var instance EventInstance
+	res := C.FMOD_Studio_EventDescription_CreateInstance(e.cptr, &instance.cptr)
+	return &instance, errs[res]
+}
+
+// Retrieves the number of instances of the event that currently exist.
+func (e *EventDescription) InstanceCount() (int, error) {
+	var count C.int
+	res := C.FMOD_Studio_EventDescription_GetInstanceCount(e.cptr, &count)
+	return int(count), errs[res]
+}
+
+// Releases all instances of the event.
+// This function immediately stops and releases all instances of the event.
+func (e *EventDescription) ReleaseAllInstances() error {
+	res := C.FMOD_Studio_EventDescription_ReleaseAllInstances(e.cptr)
+	return errs[res]
+}
@@ -0,0 +1,221 @@
+package studio
+
+/*
+#include <stdlib.h>
+#include <fmod_studio.h>
+extern FMOD_RESULT goStudioEventCallback(FMOD_STUDIO_EVENT_CALLBACK_TYPE type, FMOD_STUDIO_EVENTINSTANCE *event, void *parameters);
+*/
+import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// An instance of an FMOD Studio Event.
+// Instances are created with "EventDescription.CreateInstance".
+type EventInstance struct {
+	cptr *C.FMOD_STUDIO_EVENTINSTANCE
+}
+
+/*
+   'EventInstance' API
+*/
+
+// Checks that the EventInstance reference is valid.
+func (e *EventInstance) IsValid() bool {
+	return setBool(C.FMOD_Studio_EventInstance_IsValid(e.cptr))
+}
+
+// Retrieves the event description the instance was created from.
+func (e *EventInstance) Description() (*EventDescription, error) {
+	var description EventDescription
+	res := C.FMOD_Studio_EventInstance_GetDescription(e.cptr, &description.cptr)
+	return &description, errs[res]
+}
+
+/*
+   Playback control.
+*/
+
+// Starts playback.
+// If the instance was already playing then calling this function will restart the event.
+func (e *EventInstance) Start() error {
+	res := C.FMOD_Studio_EventInstance_Start(e.cptr)
+	return errs[res]
+}
+
+// Stops playback.
+//
+// mode: Stop mode, see "StopMode".
+func (e *EventInstance) Stop(mode StopMode) error {
+	res := C.FMOD_Studio_EventInstance_Stop(e.cptr, C.FMOD_STUDIO_STOP_MODE(mode))
+	return errs[res]
+}
+
+// Retrieves the playback state.
+// The playback state is updated by "System.Update", so a newly started instance reports "PLAYBACK_STARTING" until the next update.
+func (e *EventInstance) PlaybackState() (PlaybackState, error) {
+	var state C.FMOD_STUDIO_PLAYBACK_STATE
+	res := C.FMOD_Studio_EventInstance_GetPlaybackState(e.cptr, &state)
+	return PlaybackState(state), errs[res]
+}
+
+// Sets the pause state.
+func (e *EventInstance) SetPaused(paused bool) error {
+	res := C.FMOD_Studio_EventInstance_SetPaused(e.cptr, getBool(paused))
+	return errs[res]
+}
+
+// Retrieves the pause state.
+func (e *EventInstance) IsPaused() (bool, error) {
+	var paused C.FMOD_BOOL
+	res := C.FMOD_Studio_EventInstance_GetPaused(e.cptr, &paused)
+	return setBool(paused), errs[res]
+}
+
+// Marks the event instance for release.
+// The instance is destroyed when it stops, so a playing oneshot can be released right after "EventInstance.Start".
+// The handle becomes invalid once the instance has been destroyed.
+func (e *EventInstance) Release() error {
+	res := C.FMOD_Studio_EventInstance_Release(e.cptr)
+	return errs[res]
+}
+
+/*
+   3D attributes.
+*/
+
+// Sets the 3D attributes of the event instance.
+// An event's 3D attributes specify its position, velocity and orientation. They are used to calculate
+// the automatic parameters and to position the event relative to the listeners.
+func (e *EventInstance) Set3DAttributes(attributes Attributes3D) error {
+	cattributes := attributes.toC()
+	res := C.FMOD_Studio_EventInstance_Set3DAttributes(e.cptr, &cattributes)
+	return errs[res]
+}
+
+// Retrieves the 3D attributes of the event instance.
+func (e *EventInstance) Get3DAttributes() (Attributes3D, error) {
+	var cattributes C.FMOD_3D_ATTRIBUTES
+	var attributes Attributes3D
+	res := C.FMOD_Studio_EventInstance_Get3DAttributes(e.cptr, &cattributes)
+	attributes.fromC(cattributes)
+	return attributes, errs[res]
+}
+
+/*
+   Parameters.
+*/
+
+// Retrieves the number of parameters in the event instance.
+func (e *EventInstance) ParameterCount() (int, error) {
+	var count C.int
+	res := C.FMOD_Studio_EventInstance_GetParameterCount(e.cptr, &count)
+	return int(count), errs[res]
+}
+
+// Sets a parameter value by name.
+//
+// name: Parameter name (case-insensitive).
+//
+// value: Value for the parameter, it is clamped to the parameter's minimum and maximum.
+//
+// Automatic parameters are computed by FMOD and cannot be set.
+func (e *EventInstance) SetParameterByName(name string, value float64) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	res := C.FMOD_Studio_EventInstance_SetParameterValue(e.cptr, cname, C.float(value))
+	return errs[res]
+}
+
+// Sets a parameter value by ID.
+// Looking a parameter up by ID is faster than by name, so prefer it for parameters updated every frame.
+//
+// id: Parameter ID, see "ParameterDescription".
+//
+// value: Value for the parameter, it is clamped to the parameter's minimum and maximum.
+func (e *EventInstance) SetParameterByID(id ParameterID, value float64) error {
+	res := C.FMOD_Studio_EventInstance_SetParameterValueByIndex(e.cptr, C.int(id), C.float(value))
+	return errs[res]
+}
+
+// Sets multiple parameter values by ID in a single call.
+// ids and values must have the same length.
+func (e *EventInstance) SetParametersByIDs(ids []ParameterID, values []float64) error {
+	if len(ids) != len(values) {
+		return errors.New("ids and values must have the same length")
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	cids := make([]C.int, len(ids))
+	cvalues := make([]C.float, len(values))
+	for i := range ids {
+		cids[i] = C.int(ids[i])
+		cvalues[i] = C.float(values[i])
+	}
+	res := C.FMOD_Studio_EventInstance_SetParameterValuesByIndices(e.cptr, &cids[0], &cvalues[0], C.int(len(ids)))
+	return errs[res]
+}
+
+// Retrieves a parameter value by name.
+//
+// Returns the value set by the API and the final value, which also accounts for automation and modulation.
+// For automatic parameters both are the value computed by FMOD during the last update.
+//
+// FMOD 1.07 Studio does not report the final value separately, so it is always equal to the value.
+func (e *EventInstance) ParameterByName(name string) (value, finalValue float64, err error) {
+	var cvalue C.float
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	res := C.FMOD_Studio_EventInstance_GetParameterValue(e.cptr, cname, &cvalue)
+	return float64(cvalue), float64(cvalue), errs[res]
+}
+
+// Retrieves a parameter value by ID.
+// See "EventInstance.ParameterByName".
+func (e *EventInstance) ParameterByID(id ParameterID) (value, finalValue float64, err error) {
+	var cvalue C.float
+	res := C.FMOD_Studio_EventInstance_GetParameterValueByIndex(e.cptr, C.int(id), &cvalue)
+	return float64(cvalue), float64(cvalue), errs[res]
+}
+
+/*
+   Callbacks.
+*/
+
+// Sets the event instance callback.
+//
+// mask: The callback types to deliver, see "EventCallbackType". Use "EVENT_CALLBACK_ALL" to receive everything.
+//
+// events: Channel the callbacks are delivered on. Pass nil to remove the callback.
+//
+// FMOD raises callbacks from its own threads, so they are copied into "EventCallback" values and forwarded to events
+// from a separate goroutine, in the order they were raised. A slow receiver never stalls the mixer and no callback is dropped.
+// The callback is removed automatically when the instance is destroyed.
+func (e *EventInstance) SetCallback(mask EventCallbackType, events chan<- EventCallback) error {
+	return updateEventCallback(e.cptr, func(entry *eventCallbackEntry) {
+		if entry.queue != nil {
+			entry.queue.stop()
+			entry.queue = nil
+		}
+		if events != nil && mask != 0 {
+			entry.queue = newEventCallbackQueue(mask, events)
+		}
+	})
+}
+
+// Sets the table supplying sounds to the programmer instruments of the event instance.
+//
+// table: The sounds to play, keyed by instrument name. Pass nil to remove it.
+//
+// When a programmer instrument starts, its sound is resolved from the table by the instrument name.
+// An instrument without an entry in the table stays silent. The table may be shared by several instances
+// and can be modified while they play, for example to set the next line of dialogue.
+// Sounds handed to instruments are released automatically when the instruments are destroyed,
+// including the instruments which started before the table was removed.
+func (e *EventInstance) SetProgrammerSounds(table *ProgrammerSoundTable) error {
+	return updateEventCallback(e.cptr, func(entry *eventCallbackEntry) {
+		entry.table = table
+	})
+}
@@ -0,0 +1,156 @@
+package studio
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+// loadTestBanks loads the Studio banks found in media, and skips the test when there are none.
+func loadTestBanks(t *testing.T, system *System) []*Bank {
+	files, err := filepath.Glob("media/*.bank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no Studio banks in media")
+	}
+	var banks []*Bank
+	for _, file := range files {
+		bank, err := system.LoadBankFile(file, LOAD_BANK_NORMAL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		banks = append(banks, bank)
+	}
+	return banks
+}
+
+func TestEventInstanceParameterErrors(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var instance EventInstance
+	err = instance.SetParameterByName("Missing", 1)
+	if err == nil {
+		t.Error("expected an error for an invalid instance")
+	}
+
+	_, _, err = instance.ParameterByName("Missing")
+	if err == nil {
+		t.Error("expected an error for an invalid instance")
+	}
+
+	err = instance.SetParameterByID(-1, 1)
+	if err == nil {
+		t.Error("expected an error for an out of range ID")
+	}
+
+	_, _, err = instance.ParameterByID(1 << 20)
+	if err == nil {
+		t.Error("expected an error for an out of range ID")
+	}
+
+	err = instance.SetParametersByIDs([]ParameterID{0, 1}, []float64{1})
+	if err == nil {
+		t.Error("expected an error for IDs and values of different lengths")
+	}
+
+	err = instance.SetParametersByIDs([]ParameterID{0}, []float64{1})
+	if err == nil {
+		t.Error("expected an error for an invalid instance")
+	}
+
+	err = instance.SetParametersByIDs(nil, nil)
+	if err != nil {
+		t.Error("expected no error without parameters but got", err)
+	}
+
+	var description EventDescription
+	_, err = description.ParameterByIndex(0)
+	if err == nil {
+		t.Error("expected an error for an invalid event description")
+	}
+
+	_, err = description.Parameter("Missing")
+	if err == nil {
+		t.Error("expected an error for an invalid event description")
+	}
+
+	<-done
+}
+
+func TestEventInstanceParameterRoundTrip(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { <-done }()
+
+	tested := 0
+	for _, bank := range loadTestBanks(t, system) {
+		events, err := bank.EventList()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, event := range events {
+			parameters, err := event.Parameters()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			instance, err := event.CreateInstance()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, parameter := range parameters {
+				if !parameter.IsGameControlled() {
+					continue
+				}
+
+				middle := (parameter.Minimum + parameter.Maximum) / 2
+				err = instance.SetParameterByName(parameter.Name, middle)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				value, finalValue, err := instance.ParameterByName(parameter.Name)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if math.Abs(value-middle) > 1e-4 || finalValue != value {
+					t.Errorf("%s: expected %v by name but got %v and %v", parameter.Name, middle, value, finalValue)
+				}
+
+				err = instance.SetParameterByID(parameter.ID, parameter.Maximum)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				value, _, err = instance.ParameterByID(parameter.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if math.Abs(value-parameter.Maximum) > 1e-4 {
+					t.Errorf("%s: expected %v by ID but got %v", parameter.Name, parameter.Maximum, value)
+				}
+				tested++
+			}
+
+			err = instance.Release()
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if tested == 0 {
+		t.Skip("no game controlled parameters in the banks of media")
+	}
+}
@@ -0,0 +1,91 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/theaidem/fmod/lowlevel"
+)
+
+// A globally unique identifier, as used by FMOD Studio to identify events, buses, VCAs and banks.
+// Unlike "lowlevel.Guid" it can be printed, compared and parsed in Go.
+type GUID struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// Converts a "lowlevel.Guid" into a GUID.
+func NewGUID(id lowlevel.Guid) GUID {
+	// lowlevel.Guid and C.FMOD_GUID share the same memory layout, but cgo types are package local.
+	return guidFromC(*(*C.FMOD_GUID)(unsafe.Pointer(&id)))
+}
+
+// Converts the GUID into a "lowlevel.Guid", for use with the low level API.
+func (g GUID) Guid() lowlevel.Guid {
+	cid := guidToC(g)
+	return *(*lowlevel.Guid)(unsafe.Pointer(&cid))
+}
+
+func guidFromC(cid C.FMOD_GUID) GUID {
+	g := GUID{
+		Data1: uint32(cid.Data1),
+		Data2: uint16(cid.Data2),
+		Data3: uint16(cid.Data3),
+	}
+	for i := range g.Data4 {
+		g.Data4[i] = byte(cid.Data4[i])
+	}
+	return g
+}
+
+func guidToC(g GUID) C.FMOD_GUID {
+	var cid C.FMOD_GUID
+	cid.Data1 = C.uint(g.Data1)
+	cid.Data2 = C.ushort(g.Data2)
+	cid.Data3 = C.ushort(g.Data3)
+	for i, b := range g.Data4 {
+		cid.Data4[i] = C.uchar(b)
+	}
+	return cid
+}
+
+// Reports whether the GUID is all zeros.
+func (g GUID) IsZero() bool {
+	return g == GUID{}
+}
+
+// Formats the GUID the way FMOD Studio does, for example "{2a3e48e6-94fc-4363-9468-33d2dd4d7b00}".
+func (g GUID) String() string {
+	return fmt.Sprintf("{%08x-%04x-%04x-%x-%x}", g.Data1, g.Data2, g.Data3, g.Data4[:2], g.Data4[2:])
+}
+
+var errInvalidGUID = errors.New("invalid GUID string")
+
+// Parses a GUID string in the standard "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx" form, with or without surrounding braces.
+func ParseGUID(s string) (GUID, error) {
+	var g GUID
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = s[1 : len(s)-1]
+	}
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return g, errInvalidGUID
+	}
+	b, err := hex.DecodeString(s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36])
+	if err != nil {
+		return g, errInvalidGUID
+	}
+	g.Data1 = uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	g.Data2 = uint16(b[4])<<8 | uint16(b[5])
+	g.Data3 = uint16(b[6])<<8 | uint16(b[7])
+	copy(g.Data4[:], b[8:])
+	return g, nil
+}
@@ -0,0 +1,41 @@
+package studio
+
+import "testing"
+
+func TestGUIDString(t *testing.T) {
+	const s = "{2a3e48e6-94fc-4363-9468-33d2dd4d7b00}"
+
+	g, err := ParseGUID(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Data1 != 0x2a3e48e6 || g.Data2 != 0x94fc || g.Data3 != 0x4363 || g.Data4[0] != 0x94 || g.Data4[7] != 0x00 {
+		t.Errorf("unexpected GUID %#v", g)
+	}
+
+	if g.String() != s {
+		t.Errorf("expected %s but got %s", s, g.String())
+	}
+
+	if NewGUID(g.Guid()) != g {
+		t.Error("expected lowlevel.Guid round trip to preserve the GUID")
+	}
+
+	g2, err := ParseGUID(s[1 : len(s)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g2 != g {
+		t.Error("expected the same GUID without braces")
+	}
+}
+
+func TestParseInvalidGUID(t *testing.T) {
+	for _, s := range []string{"", "{}", "2a3e48e6-94fc-4363-9468", "2a3e48e6x94fc-4363-9468-33d2dd4d7b00", "{2a3e48e6-94fc-4363-9468-33d2dd4d7bzz}"} {
+		if _, err := ParseGUID(s); err == nil {
+			t.Errorf("expected an error for %q", s)
+		}
+	}
+}
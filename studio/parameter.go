@@ -0,0 +1,46 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+
+// Identifies a parameter within an event.
+// FMOD 1.07 Studio has no parameter IDs of its own and addresses parameters by their index in the event description,
+// so the ID is that index. It is only meaningful together with the event it was retrieved from.
+type ParameterID int
+
+// Describes an event parameter.
+type ParameterDescription struct {
+	// Name of the parameter.
+	Name string
+
+	// ID of the parameter, for use with "EventInstance.SetParameterByID".
+	ID ParameterID
+
+	// Minimum parameter value.
+	Minimum float64
+
+	// Maximum parameter value.
+	Maximum float64
+
+	// Default parameter value.
+	DefaultValue float64
+
+	// Type of the parameter.
+	Type ParameterType
+}
+
+// Reports whether the parameter value is set by the game, rather than computed automatically by FMOD (for example distance or direction).
+func (p *ParameterDescription) IsGameControlled() bool {
+	return p.Type == PARAMETER_GAME_CONTROLLED
+}
+
+func (p *ParameterDescription) fromC(cp C.FMOD_STUDIO_PARAMETER_DESCRIPTION) {
+	p.Name = C.GoString(cp.name)
+	p.ID = ParameterID(cp.index)
+	p.Minimum = float64(cp.minimum)
+	p.Maximum = float64(cp.maximum)
+	p.DefaultValue = float64(cp.defaultvalue)
+	p.Type = ParameterType(cp._type)
+}
@@ -0,0 +1,166 @@
+package studio
+
+/*
+#include <stdlib.h>
+#include <fmod_studio.h>
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/theaidem/fmod/lowlevel"
+)
+
+// Describes a sound in an audio table, as returned by "System.SoundInfo".
+// Pass it to "System.CreateSoundFromInfo" to create the sound.
+type SoundInfo struct {
+	// The file name of the bank containing the sound, or empty if the bank was loaded from memory.
+	Name string
+
+	// The mode to create the sound with.
+	Mode lowlevel.Mode
+
+	// The index of the sound to play within the bank's sound bank.
+	SubsoundIndex int
+
+	cinfo C.FMOD_STUDIO_SOUND_INFO
+}
+
+// Retrieves information for loading a sound from the audio table.
+//
+// key: The key that identifies the sound in the audio table, usually the programmer instrument name.
+//
+// The bank containing the audio table must be loaded, and the information is only valid while it stays loaded.
+func (s *System) SoundInfo(key string) (*SoundInfo, error) {
+	var info SoundInfo
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	res := C.FMOD_Studio_System_GetSoundInfo(s.cptr, ckey, &info.cinfo)
+	if res != C.FMOD_OK {
+		return nil, errs[res]
+	}
+	if info.cinfo.mode&C.FMOD_OPENMEMORY == 0 && info.cinfo.mode&C.FMOD_OPENMEMORY_POINT == 0 {
+		info.Name = C.GoString(info.cinfo.name_or_data)
+	}
+	info.Mode = lowlevel.Mode(info.cinfo.mode)
+	info.SubsoundIndex = int(info.cinfo.subsoundindex)
+	return &info, nil
+}
+
+// Creates the sound described by an audio table entry.
+//
+// info: The entry, as returned by "System.SoundInfo".
+//
+// mode: Additional mode flags, for example "lowlevel.MODE_CREATESTREAM" or "lowlevel.MODE_NONBLOCKING".
+//
+// The sound is created on the low level System of s. The entry's subsound index must be used when playing it,
+// which is what a "ProgrammerSoundTable" does for the keys set with "ProgrammerSoundTable.SetAudioTableKey".
+// The caller owns the sound and must release it, unless it is handed to a programmer instrument with "ProgrammerSoundTable.SetSound".
+func (s *System) CreateSoundFromInfo(info *SoundInfo, mode lowlevel.Mode) (*lowlevel.Sound, error) {
+	lowLevel, err := s.LowLevelSystem()
+	if err != nil {
+		return nil, err
+	}
+	var sound *C.FMOD_SOUND
+	cinfo := info.cinfo
+	res := C.FMOD_System_CreateSound((*C.FMOD_SYSTEM)(lowLevel.Pointer()), cinfo.name_or_data, cinfo.mode|C.FMOD_MODE(mode), &cinfo.exinfo, &sound)
+	if res != C.FMOD_OK {
+		return nil, errs[res]
+	}
+	return lowlevel.SoundFromPointer(unsafe.Pointer(sound)), nil
+}
+
+// A table of the sounds played by programmer instruments, keyed by instrument name.
+// The table is filled by the caller and attached to event instances with "EventInstance.SetProgrammerSounds".
+//
+// FMOD asks for a programmer sound synchronously from its own thread, so the sound is resolved from the table
+// rather than by calling back into Go code. Every sound handed to an instrument is released automatically
+// when the instrument is destroyed.
+type ProgrammerSoundTable struct {
+	system *System
+
+	mu      sync.Mutex
+	entries map[string]programmerSoundEntry
+}
+
+type programmerSoundEntry struct {
+	// A sound created by the caller, handed to a single instrument.
+	sound         *lowlevel.Sound
+	subsoundIndex int
+
+	// Otherwise an audio table key, a new sound is created from it for every instrument.
+	key  string
+	mode lowlevel.Mode
+}
+
+// Creates an empty programmer sound table.
+//
+// system: The Studio System audio table keys are looked up on, see "ProgrammerSoundTable.SetAudioTableKey".
+func NewProgrammerSoundTable(system *System) *ProgrammerSoundTable {
+	return &ProgrammerSoundTable{system: system, entries: make(map[string]programmerSoundEntry)}
+}
+
+// Sets the sound played by the next programmer instrument named name.
+//
+// sound: The sound to play, usually created with "lowlevel.System.CreateSound".
+//
+// subsoundIndex: The index of the subsound to play, or -1 to play the sound itself.
+//
+// The sound is handed to a single instrument and removed from the table, and the instrument takes ownership of it.
+// Set a new sound for every instrument that plays.
+func (t *ProgrammerSoundTable) SetSound(name string, sound *lowlevel.Sound, subsoundIndex int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries[name] = programmerSoundEntry{sound: sound, subsoundIndex: subsoundIndex}
+}
+
+// Plays an audio table entry for the programmer instruments named name.
+//
+// key: The key of the sound in the audio table, see "System.SoundInfo".
+//
+// mode: Additional mode flags, see "System.CreateSoundFromInfo".
+//
+// A new sound is created from the audio table for every instrument, so the entry stays in the table.
+// The bank containing the audio table must be loaded when the instrument plays.
+func (t *ProgrammerSoundTable) SetAudioTableKey(name, key string, mode lowlevel.Mode) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries[name] = programmerSoundEntry{key: key, mode: mode}
+}
+
+// Removes the entry for the programmer instruments named name.
+// If it is a sound which has not been handed to an instrument yet, it is returned and the caller owns it again.
+func (t *ProgrammerSoundTable) Remove(name string) *lowlevel.Sound {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry := t.entries[name]
+	delete(t.entries, name)
+	return entry.sound
+}
+
+// resolve returns the sound to play for the programmer instrument name, and the subsound index to play from it.
+func (t *ProgrammerSoundTable) resolve(name string) (*lowlevel.Sound, int, error) {
+	t.mu.Lock()
+	entry, ok := t.entries[name]
+	if ok && entry.sound != nil {
+		delete(t.entries, name)
+	}
+	t.mu.Unlock()
+	if !ok {
+		return nil, -1, errs[C.FMOD_ERR_EVENT_NOTFOUND]
+	}
+	if entry.sound != nil {
+		return entry.sound, entry.subsoundIndex, nil
+	}
+	// FMOD Studio supports looking up and creating sounds from its own callbacks.
+	info, err := t.system.SoundInfo(entry.key)
+	if err != nil {
+		return nil, -1, err
+	}
+	sound, err := t.system.CreateSoundFromInfo(info, entry.mode)
+	if err != nil {
+		return nil, -1, err
+	}
+	return sound, info.SubsoundIndex, nil
+}
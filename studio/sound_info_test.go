@@ -0,0 +1,163 @@
+package studio
+
+import (
+	"testing"
+
+	"github.com/theaidem/fmod/lowlevel"
+)
+
+func TestSoundInfoMissing(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = system.SoundInfo("Missing")
+	if err == nil {
+		t.Error("expected an error for a key without a loaded audio table")
+	}
+
+	table := NewProgrammerSoundTable(system)
+	table.SetAudioTableKey("Dialogue", "Missing", lowlevel.MODE_DEFAULT)
+	sound, _, err := table.resolve("Dialogue")
+	if err == nil || sound != nil {
+		t.Error("expected an error for a missing audio table key but got", sound, err)
+	}
+
+	<-done
+}
+
+func TestProgrammerSoundTable(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lowLevel, err := system.LowLevelSystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	censor, err := lowLevel.CreateSound("../lowlevel/media/censor.wav", lowlevel.MODE_DEFAULT, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := NewProgrammerSoundTable(system)
+	_, _, err = table.resolve("Dialogue")
+	if err == nil {
+		t.Error("expected an error for an instrument without a sound")
+	}
+
+	table.SetSound("Dialogue", censor, -1)
+	sound, subsoundIndex, err := table.resolve("Dialogue")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sound != censor || subsoundIndex != -1 {
+		t.Error("expected the sound set for the instrument but got", sound, subsoundIndex)
+	}
+
+	// A sound is handed to a single instrument.
+	_, _, err = table.resolve("Dialogue")
+	if err == nil {
+		t.Error("expected the sound to be removed once resolved")
+	}
+
+	table.SetSound("Dialogue", censor, -1)
+	if table.Remove("Dialogue") != censor {
+		t.Error("expected the unused sound to be returned when removed")
+	}
+
+	if table.Remove("Dialogue") != nil {
+		t.Error("expected no sound for a removed instrument")
+	}
+
+	err = censor.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-done
+}
+
+func TestProgrammerSoundsRegistration(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := NewProgrammerSoundTable(system)
+
+	// Nothing stays registered for an invalid instance.
+	var instance EventInstance
+	err = instance.SetProgrammerSounds(table)
+	if err == nil {
+		t.Error("expected an error for an invalid instance")
+	}
+
+	eventCallbacks.Lock()
+	_, ok := eventCallbacks.m[0]
+	eventCallbacks.Unlock()
+	if ok {
+		t.Error("expected no registration for an invalid instance")
+	}
+
+	const programmer = EVENT_CALLBACK_CREATE_PROGRAMMER_SOUND | EVENT_CALLBACK_DESTROY_PROGRAMMER_SOUND
+	entry := &eventCallbackEntry{owned: make(map[uintptr]*lowlevel.Sound), table: table}
+	if entry.mask() != programmer {
+		t.Errorf("expected mask %x with a table but got %x", programmer, entry.mask())
+	}
+
+	lowLevel, err := system.LowLevelSystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	censor, err := lowLevel.CreateSound("../lowlevel/media/censor.wav", lowlevel.MODE_DEFAULT, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A fake instance key, registered the way updateEventCallback does.
+	const key = 1
+	eventCallbacks.Lock()
+	eventCallbacks.m[key] = entry
+	eventCallbacks.Unlock()
+
+	if !ownProgrammerSound(key, entry, censor) {
+		t.Error("expected the sound to be kept by a registered instance")
+	}
+
+	// Removing the table keeps the destruction of started instruments reported.
+	entry.table = nil
+	if entry.mask() != programmer {
+		t.Errorf("expected mask %x while an instrument holds a sound but got %x", programmer, entry.mask())
+	}
+
+	releaseProgrammerSound(entry, uintptr(censor.Pointer()))
+	if len(entry.owned) != 0 || entry.mask() != 0 {
+		t.Error("expected the destroyed instrument to release its sound")
+	}
+
+	eventCallbacks.Lock()
+	delete(eventCallbacks.m, key)
+	eventCallbacks.Unlock()
+
+	// The sound of an instance destroyed while it was being created is released at once.
+	bell, err := lowLevel.CreateSound("../lowlevel/media/bell.mp3", lowlevel.MODE_DEFAULT, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ownProgrammerSound(key, entry, bell) {
+		t.Error("expected the sound not to be kept by a destroyed instance")
+	}
+
+	if len(entry.owned) != 0 {
+		t.Error("expected no sound to be kept by a destroyed instance")
+	}
+
+	<-done
+}
@@ -0,0 +1,396 @@
+package studio
+
+/*
+#include <stdlib.h>
+#include <fmod_studio.h>
+*/
+import "C"
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/theaidem/fmod/lowlevel"
+)
+
+// The main object for the FMOD Studio API.
+// Its lifetime is managed by "SystemCreate" and "System.Release".
+type System struct {
+	cptr *C.FMOD_STUDIO_SYSTEM
+}
+
+/*
+   'Studio System' API
+*/
+
+// FMOD Studio System creation function.
+// This must be called to create an FMOD Studio System object before you can do anything else.
+// The low level System object is created along with it, and can be retrieved with "System.LowLevelSystem".
+func SystemCreate() (*System, error) {
+	var s System
+	res := C.FMOD_Studio_System_Create(&s.cptr, C.FMOD_VERSION)
+	runtime.SetFinalizer(&s, (*System).Release)
+	return &s, errs[res]
+}
+
+// Initializes the Studio System, the low level System object and the sound device.
+//
+// maxchannels: The maximum number of channels to be used in FMOD. They are also called 'virtual channels' as you can play as many of these as you want, even if you only have a small number of software voices.
+//
+// studioflags: See "InitFlags". This can be a selection of flags bitwise OR'ed together to change the behaviour of FMOD Studio at initialization time.
+//
+// flags: See "lowlevel.InitFlags". This can be a selection of flags bitwise OR'ed together to change the behaviour of the low level System at initialization time.
+//
+// The low level System is initialized as part of this call, so "lowlevel.System.Init" must not be called on it.
+func (s *System) Initialize(maxchannels int, studioflags InitFlags, flags lowlevel.InitFlags, extradriverdata interface{}) error {
+	res := C.FMOD_Studio_System_Initialize(s.cptr, C.int(maxchannels), C.FMOD_STUDIO_INITFLAGS(studioflags), C.FMOD_INITFLAGS(flags), unsafe.Pointer(uintptr(extradriverdata.(int))))
+	return errs[res]
+}
+
+// Closes and frees the Studio System object and its resources, including the low level System object.
+// All handles retrieved from this object become invalid.
+func (s *System) Release() error {
+	runtime.SetFinalizer(s, nil)
+	res := C.FMOD_Studio_System_Release(s.cptr)
+	return errs[res]
+}
+
+// Checks that the System reference is valid and has been initialized.
+func (s *System) IsValid() bool {
+	return setBool(C.FMOD_Studio_System_IsValid(s.cptr))
+}
+
+// Updates the FMOD Studio System.
+// This should be called once per 'game' tick, or once per frame in your application.
+//
+// When Studio has been initialized in asynchronous mode (the default), the update queues commands for the Studio update thread,
+// otherwise all processing happens in this call.
+// "lowlevel.System.Update" is called internally, so it must not be called separately.
+func (s *System) Update() error {
+	res := C.FMOD_Studio_System_Update(s.cptr)
+	return errs[res]
+}
+
+// Block until all pending commands have been executed.
+// This function blocks the calling thread until all pending commands have been executed and all non-blocking bank loads have been completed.
+func (s *System) FlushCommands() error {
+	res := C.FMOD_Studio_System_FlushCommands(s.cptr)
+	return errs[res]
+}
+
+// Retrieves the low level System object shared with this Studio System.
+// Use it to access the "lowlevel" API, for example to create sounds or query the output mode.
+// The returned object must not be released, it is owned by the Studio System.
+func (s *System) LowLevelSystem() (*lowlevel.System, error) {
+	var system *C.FMOD_SYSTEM
+	res := C.FMOD_Studio_System_GetLowLevelSystem(s.cptr, &system)
+	return lowlevel.SystemFromPointer(unsafe.Pointer(system)), errs[res]
+}
+
+/*
+   Listeners.
+*/
+
+// Sets the 3D attributes of the listener.
+//
+// listener: Index of the listener, from 0 to "System.NumListeners" - 1.
+//
+// The Studio listener attributes replace those set with "lowlevel.System.Set3DListenerAttributes", which must not be used with Studio.
+func (s *System) SetListenerAttributes(listener int, attributes Attributes3D) error {
+	cattributes := attributes.toC()
+	res := C.FMOD_Studio_System_SetListenerAttributes(s.cptr, C.int(listener), &cattributes)
+	return errs[res]
+}
+
+// Retrieves the 3D attributes of the listener.
+func (s *System) ListenerAttributes(listener int) (Attributes3D, error) {
+	var cattributes C.FMOD_3D_ATTRIBUTES
+	var attributes Attributes3D
+	res := C.FMOD_Studio_System_GetListenerAttributes(s.cptr, C.int(listener), &cattributes)
+	attributes.fromC(cattributes)
+	return attributes, errs[res]
+}
+
+// Sets the number of listeners in the 3D sound scene.
+//
+// numlisteners: Number of listeners, from 1 to FMOD_MAX_LISTENERS (8).
+//
+// With more than one listener, each event is positioned relative to the closest listener and panned to the
+// combination of all of them, which is the typical split-screen setup.
+func (s *System) SetNumListeners(numlisteners int) error {
+	res := C.FMOD_Studio_System_SetNumListeners(s.cptr, C.int(numlisteners))
+	return errs[res]
+}
+
+// Retrieves the number of listeners.
+func (s *System) NumListeners() (int, error) {
+	var numlisteners C.int
+	res := C.FMOD_Studio_System_GetNumListeners(s.cptr, &numlisteners)
+	return int(numlisteners), errs[res]
+}
+
+// Sets the listener weighting.
+//
+// listener: Index of the listener.
+//
+// weight: Weighting value, from 0 to 1.
+//
+// Listener weighting lets a listener fade in or out of the mix, for example when a split-screen player joins or leaves.
+// A weight of 0 disables the listener without changing the number of listeners.
+func (s *System) SetListenerWeight(listener int, weight float64) error {
+	res := C.FMOD_Studio_System_SetListenerWeight(s.cptr, C.int(listener), C.float(weight))
+	return errs[res]
+}
+
+// Retrieves the listener weighting.
+func (s *System) ListenerWeight(listener int) (float64, error) {
+	var weight C.float
+	res := C.FMOD_Studio_System_GetListenerWeight(s.cptr, C.int(listener), &weight)
+	return float64(weight), errs[res]
+}
+
+/*
+   Bank loading.
+*/
+
+// Loads the metadata of a Studio bank from file.
+//
+// filename: Name of the bank file on disk.
+//
+// flags: Flags to control bank loading. See "LoadBankFlags".
+//
+// Sample data must be loaded separately. Loading a bank only loads its metadata.
+func (s *System) LoadBankFile(filename string, flags LoadBankFlags) (*Bank, error) {
+	var bank Bank
+	cfilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cfilename))
+	res := C.FMOD_Studio_System_LoadBankFile(s.cptr, cfilename, C.FMOD_STUDIO_LOAD_BANK_FLAGS(flags), &bank.cptr)
+	return &bank, errs[res]
+}
+
+// Loads the metadata of a Studio bank from memory.
+//
+// data: The whole bank file contents.
+//
+// flags: Flags to control bank loading. See "LoadBankFlags".
+//
+// FMOD duplicates the memory into its own buffers, so data can be reused or discarded once this function returns.
+func (s *System) LoadBankMemory(data []byte, flags LoadBankFlags) (*Bank, error) {
+	var bank Bank
+	if len(data) == 0 {
+		return &bank, errs[C.FMOD_ERR_INVALID_PARAM]
+	}
+	cdata := C.CBytes(data)
+	defer C.free(cdata)
+	res := C.FMOD_Studio_System_LoadBankMemory(s.cptr, (*C.char)(cdata), C.int(len(data)), C.FMOD_STUDIO_LOAD_MEMORY, C.FMOD_STUDIO_LOAD_BANK_FLAGS(flags), &bank.cptr)
+	return &bank, errs[res]
+}
+
+// Unloads all currently loaded banks.
+func (s *System) UnloadAll() error {
+	res := C.FMOD_Studio_System_UnloadAll(s.cptr)
+	return errs[res]
+}
+
+/*
+   Lookup functions.
+*/
+
+// Retrieves an EventDescription.
+//
+// path: The path (for example "event:/UI/Cancel") or the ID string (for example "{2a3e48e6-94fc-4363-9468-33d2dd4d7b00}") of the event.
+//
+// This function allows you to retrieve a handle to any loaded event description.
+// A path lookup requires the strings bank to be loaded.
+func (s *System) Event(path string) (*EventDescription, error) {
+	var event EventDescription
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	res := C.FMOD_Studio_System_GetEvent(s.cptr, cpath, &event.cptr)
+	return &event, errs[res]
+}
+
+// Retrieves an EventDescription by ID.
+func (s *System) EventByID(id GUID) (*EventDescription, error) {
+	var event EventDescription
+	cid := guidToC(id)
+	res := C.FMOD_Studio_System_GetEventByID(s.cptr, &cid, &event.cptr)
+	return &event, errs[res]
+}
+
+// Retrieves a loaded Bank.
+//
+// path: The bank path (for example "bank:/Weapons") or the ID string of the bank.
+func (s *System) Bank(path string) (*Bank, error) {
+	var bank Bank
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	res := C.FMOD_Studio_System_GetBank(s.cptr, cpath, &bank.cptr)
+	return &bank, errs[res]
+}
+
+// Retrieves a loaded Bank by ID.
+func (s *System) BankByID(id GUID) (*Bank, error) {
+	var bank Bank
+	cid := guidToC(id)
+	res := C.FMOD_Studio_System_GetBankByID(s.cptr, &cid, &bank.cptr)
+	return &bank, errs[res]
+}
+
+// Retrieves a Bus.
+//
+// path: The bus path (for example "bus:/SFX/Ambience", or "bus:/" for the master bus) or the ID string of the bus.
+//
+// Returns "ErrNotFound" if no loaded bank contains the bus.
+func (s *System) Bus(path string) (*Bus, error) {
+	var bus Bus
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	res := C.FMOD_Studio_System_GetBus(s.cptr, cpath, &bus.cptr)
+	return &bus, errs[res]
+}
+
+// Retrieves a Bus by ID.
+// Returns "ErrNotFound" if no loaded bank contains the bus.
+func (s *System) BusByID(id GUID) (*Bus, error) {
+	var bus Bus
+	cid := guidToC(id)
+	res := C.FMOD_Studio_System_GetBusByID(s.cptr, &cid, &bus.cptr)
+	return &bus, errs[res]
+}
+
+// Retrieves a VCA.
+//
+// path: The VCA path (for example "vca:/Music") or the ID string of the VCA.
+//
+// Returns "ErrNotFound" if no loaded bank contains the VCA.
+func (s *System) VCA(path string) (*VCA, error) {
+	var vca VCA
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	res := C.FMOD_Studio_System_GetVCA(s.cptr, cpath, &vca.cptr)
+	return &vca, errs[res]
+}
+
+// Retrieves a VCA by ID.
+// Returns "ErrNotFound" if no loaded bank contains the VCA.
+func (s *System) VCAByID(id GUID) (*VCA, error) {
+	var vca VCA
+	cid := guidToC(id)
+	res := C.FMOD_Studio_System_GetVCAByID(s.cptr, &cid, &vca.cptr)
+	return &vca, errs[res]
+}
+
+// Retrieves the ID for a bank, event, snapshot, bus or VCA.
+//
+// path: The path of the object, for example "event:/UI/Cancel" or "bus:/SFX".
+//
+// The strings bank must be loaded for this function to succeed. It can be used to validate
+// that a path exists in the loaded banks without creating any object.
+func (s *System) LookupID(path string) (GUID, error) {
+	var id C.FMOD_GUID
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	res := C.FMOD_Studio_System_LookupID(s.cptr, cpath, &id)
+	return guidFromC(id), errs[res]
+}
+
+// Retrieves the path for a bank, event, snapshot, bus or VCA.
+// The strings bank must be loaded for this function to succeed.
+func (s *System) LookupPath(id GUID) (string, error) {
+	cid := guidToC(id)
+	return getString(func(buf *C.char, size C.int, retrieved *C.int) C.FMOD_RESULT {
+		return C.FMOD_Studio_System_LookupPath(s.cptr, &cid, buf, size, retrieved)
+	})
+}
+
+// Retrieves the number of loaded banks.
+func (s *System) BankCount() (int, error) {
+	var count C.int
+	res := C.FMOD_Studio_System_GetBankCount(s.cptr, &count)
+	return int(count), errs[res]
+}
+
+// Retrieves all loaded banks.
+func (s *System) BankList() ([]*Bank, error) {
+	count, err := s.BankCount()
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	carray := make([]*C.FMOD_STUDIO_BANK, count)
+	var retrieved C.int
+	res := C.FMOD_Studio_System_GetBankList(s.cptr, &carray[0], C.int(count), &retrieved)
+	banks := make([]*Bank, int(retrieved))
+	for i := range banks {
+		banks[i] = &Bank{cptr: carray[i]}
+	}
+	return banks, errs[res]
+}
+
+/*
+   Command capture and replay.
+*/
+
+// Recording commands issued on the Studio System, for later replay with "System.LoadCommandReplay".
+//
+// filename: The name of the file to which the recorded commands are written.
+//
+// flags: Flags that control command capturing, see "CommandCaptureFlags".
+//
+// The capture contains every Studio API call, so it can be used to reproduce a session without the game.
+func (s *System) StartCommandCapture(filename string, flags CommandCaptureFlags) error {
+	cfilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cfilename))
+	res := C.FMOD_Studio_System_StartCommandCapture(s.cptr, cfilename, C.FMOD_STUDIO_COMMANDCAPTURE_FLAGS(flags))
+	return errs[res]
+}
+
+// Stop recording Studio commands.
+func (s *System) StopCommandCapture() error {
+	res := C.FMOD_Studio_System_StopCommandCapture(s.cptr)
+	return errs[res]
+}
+
+// Load a command replay recorded with "System.StartCommandCapture".
+//
+// filename: The name of the file from which to load the command replay.
+//
+// flags: Flags that control the command replay, see "CommandReplayFlags".
+//
+// The replay must be released with "CommandReplay.Release" when it is no longer needed.
+func (s *System) LoadCommandReplay(filename string, flags CommandReplayFlags) (*CommandReplay, error) {
+	var replay CommandReplay
+	cfilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cfilename))
+	res := C.FMOD_Studio_System_LoadCommandReplay(s.cptr, cfilename, C.FMOD_STUDIO_COMMANDREPLAY_FLAGS(flags), &replay.cptr)
+	return &replay, errs[res]
+}
+
+/*
+   Profiling.
+*/
+
+// Retrieves the amount of CPU used for different parts of the Studio engine.
+// FMOD 1.07 only reports system wide usage, there is no per bus or per event instance breakdown.
+func (s *System) CPUUsage() (CPUUsage, error) {
+	var cusage C.FMOD_STUDIO_CPU_USAGE
+	var usage CPUUsage
+	res := C.FMOD_Studio_System_GetCPUUsage(s.cptr, &cusage)
+	usage.fromC(cusage)
+	return usage, errs[res]
+}
+
+// Retrieves buffer usage information.
+// Poll it regularly and check "BufferInfo.Stalled" to detect command buffer overflows, which stall the game thread.
+func (s *System) BufferUsage() (BufferUsage, error) {
+	var cusage C.FMOD_STUDIO_BUFFER_USAGE
+	var usage BufferUsage
+	res := C.FMOD_Studio_System_GetBufferUsage(s.cptr, &cusage)
+	usage.fromC(cusage)
+	return usage, errs[res]
+}
+
+// Resets the peak usage and stall counts of the buffers reported by "System.BufferUsage".
+func (s *System) ResetBufferUsage() error {
+	res := C.FMOD_Studio_System_ResetBufferUsage(s.cptr)
+	return errs[res]
+}
@@ -0,0 +1,189 @@
+package studio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theaidem/fmod/lowlevel"
+)
+
+func NewSystem(duration time.Duration) (*System, chan bool, error) {
+	done := make(chan bool)
+
+	system, err := SystemCreate()
+	if err != nil {
+		return nil, done, err
+	}
+
+	lowLevel, err := system.LowLevelSystem()
+	if err != nil {
+		return nil, done, err
+	}
+
+	// Must be selected before the Studio System is initialized
+	err = lowLevel.SetOutput(lowlevel.OUTPUTTYPE_NOSOUND)
+	if err != nil {
+		return nil, done, err
+	}
+
+	err = system.Initialize(32, INIT_NORMAL, lowlevel.INIT_NORMAL, 0)
+	if err != nil {
+		return nil, done, err
+	}
+
+	go func() {
+
+		defer func() {
+			// Manualy Release System
+			err := system.Release()
+			if err != nil {
+				panic(err)
+			}
+		}()
+
+		for {
+			select {
+			case <-time.After(duration):
+				done <- true
+				return
+			}
+
+			err := system.Update()
+			if err != nil {
+				panic(err)
+			}
+		}
+	}()
+
+	return system, done, nil
+}
+
+func TestSystemInstance(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !system.IsValid() {
+		t.Error("expected valid system")
+	}
+
+	lowLevel, err := system.LowLevelSystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := lowLevel.Version()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != lowlevel.VERSION {
+		t.Errorf("expected version %x but got %x", lowlevel.VERSION, v)
+	}
+
+	output, err := lowLevel.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if output != lowlevel.OUTPUTTYPE_NOSOUND {
+		t.Error("expected NOSOUND output but got", output)
+	}
+
+	<-done
+}
+
+func TestSystemLoadMissingBank(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = system.LoadBankFile("media/missing.bank", LOAD_BANK_NORMAL)
+	if err == nil {
+		t.Error("expected an error for a missing bank file")
+	}
+
+	_, err = system.Event("event:/Missing")
+	if err == nil {
+		t.Error("expected an error for a missing event")
+	}
+
+	count, err := system.BankCount()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Error("expected 0 banks but got", count)
+	}
+
+	<-done
+}
+
+func TestSystemUsage(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = system.CPUUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = system.ResetBufferUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := system.BufferUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if usage.CommandQueue.Capacity <= 0 {
+		t.Error("expected a command queue capacity but got", usage.CommandQueue.Capacity)
+	}
+
+	if usage.CommandQueue.Stalled() {
+		t.Error("expected no command queue stalls after reset")
+	}
+
+	<-done
+}
+
+func TestSystemLookupMissingID(t *testing.T) {
+	system, done, err := NewSystem(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := ParseGUID("{2a3e48e6-94fc-4363-9468-33d2dd4d7b00}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = system.EventByID(id)
+	if err == nil {
+		t.Error("expected an error for a missing event ID")
+	}
+
+	_, err = system.BankByID(id)
+	if err == nil {
+		t.Error("expected an error for a missing bank ID")
+	}
+
+	_, err = system.BusByID(id)
+	if err == nil {
+		t.Error("expected an error for a missing bus ID")
+	}
+
+	_, err = system.VCAByID(id)
+	if err == nil {
+		t.Error("expected an error for a missing VCA ID")
+	}
+
+	<-done
+}
@@ -0,0 +1,78 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+
+// Performance information for the FMOD Studio and low level systems, as returned by "System.CPUUsage".
+// All values are percentages of a single CPU core.
+type CPUUsage struct {
+	// DSP mixing engine CPU usage.
+	DSP float64
+
+	// Streaming engine CPU usage.
+	Stream float64
+
+	// Geometry engine CPU usage.
+	Geometry float64
+
+	// "lowlevel.System.Update" CPU usage.
+	Update float64
+
+	// Studio engine CPU usage.
+	Studio float64
+}
+
+func (c *CPUUsage) fromC(cc C.FMOD_STUDIO_CPU_USAGE) {
+	c.DSP = float64(cc.dspusage)
+	c.Stream = float64(cc.streamusage)
+	c.Geometry = float64(cc.geometryusage)
+	c.Update = float64(cc.updateusage)
+	c.Studio = float64(cc.studiousage)
+}
+
+// Information for a single buffer in FMOD Studio, see "BufferUsage".
+type BufferInfo struct {
+	// Current buffer usage in bytes.
+	CurrentUsage int
+
+	// Peak buffer usage in bytes.
+	PeakUsage int
+
+	// Buffer capacity in bytes.
+	Capacity int
+
+	// Cumulative number of stalls due to buffer overflow.
+	StallCount int
+
+	// Cumulative amount of time stalled due to buffer overflow, in seconds.
+	StallTime float64
+}
+
+// Reports whether the buffer has overflowed since the last "System.ResetBufferUsage".
+func (b BufferInfo) Stalled() bool {
+	return b.StallCount > 0
+}
+
+func (b *BufferInfo) fromC(cb C.FMOD_STUDIO_BUFFER_INFO) {
+	b.CurrentUsage = int(cb.currentusage)
+	b.PeakUsage = int(cb.peakusage)
+	b.Capacity = int(cb.capacity)
+	b.StallCount = int(cb.stallcount)
+	b.StallTime = float64(cb.stalltime)
+}
+
+// Information for FMOD Studio buffer usage, as returned by "System.BufferUsage".
+type BufferUsage struct {
+	// Information for the Studio asynchronous command buffer. Stalls here block the calling thread in API calls until "System.Update" has processed enough commands.
+	CommandQueue BufferInfo
+
+	// Information for the Studio handle table.
+	Handle BufferInfo
+}
+
+func (b *BufferUsage) fromC(cb C.FMOD_STUDIO_BUFFER_USAGE) {
+	b.CommandQueue.fromC(cb.studiocommandqueue)
+	b.Handle.fromC(cb.studiohandle)
+}
@@ -0,0 +1,41 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+import "unsafe"
+
+func getBool(b bool) C.FMOD_BOOL {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func setBool(b C.FMOD_BOOL) bool {
+	if b == 1 {
+		return true
+	}
+	return false
+}
+
+// getString calls one of the Studio "get path" style functions twice:
+// first to find out the required buffer size, then to fill the buffer.
+func getString(get func(buf *C.char, size C.int, retrieved *C.int) C.FMOD_RESULT) (string, error) {
+	var size C.int
+	res := get(nil, 0, &size)
+	if res != C.FMOD_OK && res != C.FMOD_ERR_TRUNCATED {
+		return "", errs[res]
+	}
+	if size <= 0 {
+		return "", nil
+	}
+	buf := make([]byte, size)
+	res = get((*C.char)(unsafe.Pointer(&buf[0])), size, &size)
+	if size > 0 {
+		// retrieved includes the null terminator.
+		size--
+	}
+	return string(buf[:size]), errs[res]
+}
@@ -0,0 +1,51 @@
+package studio
+
+/*
+#include <fmod_studio.h>
+*/
+import "C"
+
+// Represents a global mixer VCA.
+// VCAs are retrieved with "System.VCA".
+type VCA struct {
+	cptr *C.FMOD_STUDIO_VCA
+}
+
+/*
+   'VCA' API
+*/
+
+// Checks that the VCA reference is valid.
+func (v *VCA) IsValid() bool {
+	return setBool(C.FMOD_Studio_VCA_IsValid(v.cptr))
+}
+
+// Retrieves the GUID of the VCA.
+func (v *VCA) ID() (GUID, error) {
+	var id C.FMOD_GUID
+	res := C.FMOD_Studio_VCA_GetID(v.cptr, &id)
+	return guidFromC(id), errs[res]
+}
+
+// Retrieves the path of the VCA, for example "vca:/Music".
+// The strings bank must be loaded for this function to succeed.
+func (v *VCA) Path() (string, error) {
+	return getString(func(buf *C.char, size C.int, retrieved *C.int) C.FMOD_RESULT {
+		return C.FMOD_Studio_VCA_GetPath(v.cptr, buf, size, retrieved)
+	})
+}
+
+// Sets the volume (fader level) of the VCA.
+//
+// volume: Linear volume level, default = 1.0.
+func (v *VCA) SetVolume(volume float64) error {
+	res := C.FMOD_Studio_VCA_SetFaderLevel(v.cptr, C.float(volume))
+	return errs[res]
+}
+
+// Retrieves the volume (fader level) of the VCA.
+func (v *VCA) Volume() (float64, error) {
+	var volume C.float
+	res := C.FMOD_Studio_VCA_GetFaderLevel(v.cptr, &volume)
+	return float64(volume), errs[res]
+}